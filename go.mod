@@ -7,6 +7,10 @@ require (
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.24.1
 )
 
+// The pugo client is patched to let the provider configure its transport,
+// see third_party/pugo/README.md.
+replace github.com/devans10/pugo/flasharray => ./third_party/pugo/flasharray
+
 require (
 	github.com/agext/levenshtein v1.2.3 // indirect
 	github.com/apparentlymart/go-cidr v1.1.0 // indirect
@@ -19,6 +19,7 @@ package purestorage
 import (
 	"fmt"
 	"log"
+	"sync"
 
 	"github.com/devans10/pugo/flasharray"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -27,7 +28,8 @@ import (
 // Config is the configuration for the Purestorage FlashArray Go Client.
 // It holds the connection information to connect to the array API.
 // Either the Username and Password or the API Token should be provided,
-// but not both.
+// but not both. The connection information can also come from a profile
+// of the profiles file.
 type Config struct {
 	Username      string
 	Password      string
@@ -38,6 +40,8 @@ type Config struct {
 	SslCert       bool
 	UserAgent     string
 	RequestKwargs map[string]string
+	TLS           *TLSConfig
+	Retry         *RetryConfig
 }
 
 // NewConfig returns a new Config from a supplied ResourceData.
@@ -49,14 +53,6 @@ func NewConfig(d *schema.ResourceData) (*Config, error) {
 	password := d.Get("password").(string)
 	apitoken := d.Get("api_token").(string)
 
-	if (username != "") && (password != "") && (apitoken != "") {
-		return nil, fmt.Errorf("Username and Password or API Token must be provided, but not both")
-	}
-
-	if (username != "") && (password == "") {
-		return nil, fmt.Errorf("Password must be provided with Username")
-	}
-
 	requestKwargs := make(map[string]string)
 
 	for key, value := range d.Get("request_kwargs").(map[string]interface{}) {
@@ -66,6 +62,16 @@ func NewConfig(d *schema.ResourceData) (*Config, error) {
 		requestKwargs[strKey] = strValue
 	}
 
+	tlsConfig, err := expandTLSConfig(d.Get("tls").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	retryConfig, err := expandRetryConfig(d.Get("retry").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Config{
 		Username:      username,
 		Password:      password,
@@ -76,15 +82,85 @@ func NewConfig(d *schema.ResourceData) (*Config, error) {
 		SslCert:       d.Get("ssl_cert").(bool),
 		UserAgent:     d.Get("user_agent").(string),
 		RequestKwargs: requestKwargs,
+		TLS:           tlsConfig,
+		Retry:         retryConfig,
+	}
+
+	if name := d.Get("profile").(string); name != "" {
+		p, err := loadProfile(d.Get("profiles_file").(string), name)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.apply(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkCredentials(c.Username, c.Password, c.APIToken); err != nil {
+		return nil, err
 	}
 
 	return c, nil
 }
 
+// checkCredentials checks that either a username and password or an API
+// token are provided.
+func checkCredentials(username string, password string, apitoken string) error {
+	if (username != "") && (password != "") && (apitoken != "") {
+		return fmt.Errorf("Username and Password or API Token must be provided, but not both")
+	}
+
+	if (username != "") && (password == "") {
+		return fmt.Errorf("Password must be provided with Username")
+	}
+	return nil
+}
+
+// NewArrayConfigs returns the Configs of the arrays in the arrays block,
+// keyed by alias. The arrays share the settings of the provider other than
+// the target and credentials, and the TLS settings if they have a tls block.
+func NewArrayConfigs(d *schema.ResourceData, c *Config) (map[string]*Config, error) {
+	configs := make(map[string]*Config)
+	for _, a := range d.Get("arrays").([]interface{}) {
+		array := a.(map[string]interface{})
+		alias := array["alias"].(string)
+		if _, ok := configs[alias]; ok {
+			return nil, fmt.Errorf("array alias '%s' is used more than once", alias)
+		}
+		username := array["username"].(string)
+		password := array["password"].(string)
+		apitoken := array["api_token"].(string)
+		if err := checkCredentials(username, password, apitoken); err != nil {
+			return nil, fmt.Errorf("array '%s': %s", alias, err)
+		}
+
+		tlsConfig, err := expandTLSConfig(array["tls"].([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("array '%s': %s", alias, err)
+		}
+
+		config := *c
+		if tlsConfig != nil {
+			config.TLS = tlsConfig
+		}
+		config.Target = array["target"].(string)
+		config.Username = username
+		config.Password = password
+		config.APIToken = apitoken
+		configs[alias] = &config
+	}
+	return configs, nil
+}
+
 // Client returns a new client for accessing flasharray.
 func (c *Config) Client() (*flasharray.Client, error) {
 
-	client, err := flasharray.NewClient(c.Target, c.Username, c.Password, c.APIToken, c.RestVersion, c.VerifyHTTPS, c.SslCert, c.UserAgent, c.RequestKwargs)
+	transport, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := flasharray.NewClientWithTransport(c.Target, c.Username, c.Password, c.APIToken, c.RestVersion, c.VerifyHTTPS, c.SslCert, c.UserAgent, c.RequestKwargs, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -93,3 +169,46 @@ func (c *Config) Client() (*flasharray.Client, error) {
 
 	return client, err
 }
+
+// clientPool holds the clients of the arrays managed by the provider, keyed
+// by alias. The array of the provider target and credentials has the empty
+// alias. A client is created when it is first used.
+type clientPool struct {
+	mu      sync.Mutex
+	configs map[string]*Config
+	clients map[string]*flasharray.Client
+}
+
+func newClientPool(configs map[string]*Config) *clientPool {
+	return &clientPool{configs: configs, clients: make(map[string]*flasharray.Client)}
+}
+
+// hasArray returns whether an array with the given alias is configured in
+// the arrays block.
+func (p *clientPool) hasArray(alias string) bool {
+	_, ok := p.configs[alias]
+	return alias != "" && ok
+}
+
+// client returns the client of the array with the given alias.
+func (p *clientPool) client(alias string) (*flasharray.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.clients[alias]; ok {
+		return client, nil
+	}
+	config, ok := p.configs[alias]
+	if !ok {
+		return nil, fmt.Errorf("array '%s' is not configured in the arrays block of the provider", alias)
+	}
+	if alias == "" && config.Target == "" {
+		return nil, fmt.Errorf("target is not configured, set target or the array argument")
+	}
+	client, err := config.Client()
+	if err != nil {
+		return nil, err
+	}
+	p.clients[alias] = client
+	return client, nil
+}
@@ -0,0 +1,211 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultProfilesFile is the profiles file used when profiles_file is not
+// set.
+const defaultProfilesFile = "~/.purefa/config"
+
+// profileKeys are the settings a profile can hold.
+var profileKeys = map[string]bool{
+	"target":         true,
+	"api_token":      true,
+	"api_token_file": true,
+	"ca_bundle":      true,
+	"rest_version":   true,
+}
+
+// profile is a named set of connection settings read from the profiles file.
+type profile struct {
+	name     string
+	path     string
+	settings map[string]string
+}
+
+// loadProfile reads the profile with the given name from an INI profiles
+// file, in which every profile is a section:
+//
+//	[prod]
+//	target         = flasharray1.example.com
+//	api_token_file = ~/.purefa/prod.token
+//	ca_bundle      = /etc/pki/internal-ca.pem
+//	rest_version   = 1.17
+func loadProfile(path string, name string) (*profile, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("profile '%s' can not be read: %s", name, err)
+	}
+	defer f.Close()
+
+	var p *profile
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%s:%d: section header is not closed", path, n)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == name {
+				if p != nil {
+					return nil, fmt.Errorf("%s:%d: profile '%s' is defined more than once", path, n, name)
+				}
+				p = &profile{name: name, path: path, settings: make(map[string]string)}
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, n)
+		}
+		if section != name {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if !profileKeys[key] {
+			return nil, fmt.Errorf("%s:%d: unknown setting '%s' in profile '%s'", path, n, key, name)
+		}
+		p.settings[key] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, fmt.Errorf("profile '%s' is not defined in %s", name, path)
+	}
+
+	if p.settings["api_token"] != "" && p.settings["api_token_file"] != "" {
+		return nil, fmt.Errorf("profile '%s' sets both api_token and api_token_file", name)
+	}
+	if file := p.settings["api_token_file"]; file != "" {
+		file, err := expandHome(file)
+		if err != nil {
+			return nil, err
+		}
+		token, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("profile '%s': api_token_file can not be read: %s", name, err)
+		}
+		p.settings["api_token"] = strings.TrimSpace(string(token))
+	}
+	if file := p.settings["ca_bundle"]; file != "" {
+		file, err := expandHome(file)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(file); err != nil {
+			return nil, fmt.Errorf("profile '%s': ca_bundle can not be read: %s", name, err)
+		}
+		p.settings["ca_bundle"] = file
+	}
+	return p, nil
+}
+
+// expandHome replaces a leading ~ of a path with the home directory.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// setting is a connection setting of the provider, with the environment
+// variable it can also be set with.
+type setting struct {
+	name string
+	env  string
+}
+
+// source describes where the value of a provider setting comes from. The
+// environment variables are only the default of the provider arguments, so
+// the value is taken to come from the environment when it matches it.
+func (s setting) source(value string) string {
+	if s.env != "" && os.Getenv(s.env) == value {
+		return fmt.Sprintf("the %s environment variable", s.env)
+	}
+	return fmt.Sprintf("the %s provider argument", s.name)
+}
+
+// apply applies the profile to the Config. A setting of the profile that is
+// also set, to a different value, by the provider arguments or environment
+// variables is an error, as are the username and password together with a
+// profile that holds an API token.
+func (p *profile) apply(c *Config) error {
+	settings := []struct {
+		setting
+		value *string
+	}{
+		{setting{"target", "PURE_TARGET"}, &c.Target},
+		{setting{"api_token", "PURE_APITOKEN"}, &c.APIToken},
+		{setting{"rest_version", ""}, &c.RestVersion},
+	}
+	for _, s := range settings {
+		value, ok := p.settings[s.name]
+		if !ok || value == "" {
+			continue
+		}
+		if *s.value != "" && *s.value != value {
+			return fmt.Errorf("%s is set by both profile '%s' and %s, remove one of them", s.name, p.name, s.source(*s.value))
+		}
+		*s.value = value
+	}
+
+	if p.settings["api_token"] != "" {
+		credentials := []struct {
+			setting
+			value string
+		}{
+			{setting{"username", "PURE_USERNAME"}, c.Username},
+			{setting{"password", "PURE_PASSWORD"}, c.Password},
+		}
+		for _, s := range credentials {
+			if s.value != "" {
+				return fmt.Errorf("profile '%s' sets an API token, which can not be used with %s", p.name, s.source(s.value))
+			}
+		}
+	}
+	if ca := p.settings["ca_bundle"]; ca != "" {
+		if c.TLS == nil {
+			c.TLS = &TLSConfig{}
+		}
+		if c.TLS.CAFile != "" || c.TLS.CAPEM != "" {
+			return fmt.Errorf("the CA bundle is set by both profile '%s' and the tls provider argument, remove one of them", p.name)
+		}
+		c.TLS.CAFile = ca
+	}
+	return nil
+}
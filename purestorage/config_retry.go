@@ -0,0 +1,394 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// RetryConfig is the policy used to retry the requests to the array API that
+// fail with a transient error.
+type RetryConfig struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	StatusCodes []int
+	Errors      []string
+}
+
+// defaultRetryConfig is the retry policy used without a retry block, and the
+// defaults of the retry block.
+var defaultRetryConfig = RetryConfig{
+	MaxAttempts: 4,
+	MinBackoff:  time.Second,
+	MaxBackoff:  30 * time.Second,
+	StatusCodes: []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	Errors:      []string{"Could not execute"},
+}
+
+// retrySchema returns the schema of the retry block of the provider.
+func retrySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Policy to retry the requests to the array that fail with a transient error.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_attempts": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultRetryConfig.MaxAttempts,
+					Description:  "Number of times a request is sent before its error is returned. 1 disables the retries.",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"min_backoff": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      defaultRetryConfig.MinBackoff.String(),
+					Description:  "Wait before the first retry. It doubles on every retry, with jitter.",
+					ValidateFunc: validateDuration,
+				},
+				"max_backoff": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      defaultRetryConfig.MaxBackoff.String(),
+					Description:  "Longest wait between two attempts.",
+					ValidateFunc: validateDuration,
+				},
+				"retryable_status_codes": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "HTTP status codes of the responses to retry.",
+					Elem:        &schema.Schema{Type: schema.TypeInt},
+				},
+				"retryable_errors": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Messages of the array errors to retry.",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// validateDuration checks that a value is a duration parsed by
+// time.ParseDuration, and not negative.
+func validateDuration(i interface{}, k string) ([]string, []error) {
+	d, err := time.ParseDuration(i.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be a duration like 500ms or 2s, got %s", k, i)}
+	}
+	if d < 0 {
+		return nil, []error{fmt.Errorf("%s can not be negative, got %s", k, i)}
+	}
+	return nil, nil
+}
+
+// expandRetryConfig returns the RetryConfig of a retry block, or nil if the
+// block is not set.
+func expandRetryConfig(l []interface{}) (*RetryConfig, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+	m := l[0].(map[string]interface{})
+	r := &RetryConfig{
+		MaxAttempts: m["max_attempts"].(int),
+		StatusCodes: defaultRetryConfig.StatusCodes,
+		Errors:      defaultRetryConfig.Errors,
+	}
+
+	var err error
+	if r.MinBackoff, err = time.ParseDuration(m["min_backoff"].(string)); err != nil {
+		return nil, fmt.Errorf("retry: min_backoff: %s", err)
+	}
+	if r.MaxBackoff, err = time.ParseDuration(m["max_backoff"].(string)); err != nil {
+		return nil, fmt.Errorf("retry: max_backoff: %s", err)
+	}
+	if r.MaxBackoff < r.MinBackoff {
+		return nil, fmt.Errorf("retry: max_backoff can not be shorter than min_backoff")
+	}
+
+	if codes := m["retryable_status_codes"].([]interface{}); len(codes) > 0 {
+		r.StatusCodes = make([]int, len(codes))
+		for i, code := range codes {
+			r.StatusCodes[i] = code.(int)
+		}
+	}
+	if msgs := m["retryable_errors"].([]interface{}); len(msgs) > 0 {
+		r.Errors = make([]string, len(msgs))
+		for i, msg := range msgs {
+			r.Errors[i] = msg.(string)
+		}
+	}
+	return r, nil
+}
+
+// backoff returns the wait before the given retry: the min backoff doubled
+// on every retry up to the max backoff, of which a random half is waited.
+func (r *RetryConfig) backoff(retry int) time.Duration {
+	d := r.MinBackoff
+	for i := 1; i < retry && d < r.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.MaxBackoff {
+		d = r.MaxBackoff
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryTransport is an http.RoundTripper that retries the requests that fail
+// with a transient error, and logs in again when the session expired.
+//
+// The array answers a request of an expired session with 401. The session
+// is then renewed, with the API token or the API token of the username and
+// password, and the request sent again with the new session cookie. The
+// cookie is also set on the response, so that the cookie jar of the client
+// uses it for the next requests.
+type retryTransport struct {
+	transport http.RoundTripper
+	retry     RetryConfig
+
+	mu       sync.Mutex
+	username string
+	password string
+	apiToken string
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.GetBody == nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(b)), nil
+		}
+	}
+
+	var session []*http.Cookie
+	for attempt := 1; ; attempt++ {
+		r, err := t.clone(req, session)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := t.transport.RoundTrip(r)
+
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && session == nil && !isAuthRequest(req) {
+			resp.Body.Close()
+			log.Printf("[DEBUG] %s %s: session expired, logging in again", req.Method, req.URL.Path)
+			if session, err = t.login(req); err != nil {
+				return nil, err
+			}
+			attempt--
+			continue
+		}
+
+		reason, resp := t.retryable(req, resp, err)
+		if reason == "" || attempt >= t.retry.MaxAttempts {
+			if resp != nil {
+				for _, c := range session {
+					resp.Header.Add("Set-Cookie", c.String())
+				}
+			}
+			return resp, err
+		}
+
+		wait := t.retry.backoff(attempt)
+		if resp != nil {
+			if after, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && time.Duration(after)*time.Second > wait {
+				wait = time.Duration(after) * time.Second
+				if wait > t.retry.MaxBackoff {
+					wait = t.retry.MaxBackoff
+				}
+			}
+			resp.Body.Close()
+		}
+		log.Printf("[DEBUG] %s %s: %s, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, reason, wait, attempt, t.retry.MaxAttempts)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// clone returns a copy of the request with a fresh body, and with the
+// session cookies if the session was renewed.
+func (t *retryTransport) clone(req *http.Request, session []*http.Cookie) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	if session != nil {
+		r.Header.Del("Cookie")
+		for _, c := range session {
+			r.AddCookie(c)
+		}
+	}
+	return r, nil
+}
+
+// retryable returns why the response or error should be retried, or "" if
+// it should not. The body of an error response is read to match it against
+// the retryable errors, and replaced in the returned response.
+//
+// A request that is not idempotent may have been executed by the array when
+// the connection failed or the array answered with a server error, so it is
+// only retried when the array tells that it did not execute it: on 429, 503
+// or one of the retryable errors.
+func (t *retryTransport) retryable(req *http.Request, resp *http.Response, err error) (string, *http.Response) {
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+	if err != nil {
+		if !idempotent {
+			return "", resp
+		}
+		// Only the errors of a connection closed by the array are retried,
+		// not those of an array that can not be reached or verified.
+		if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return err.Error(), resp
+		}
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return err.Error(), resp
+		}
+		return "", resp
+	}
+	if resp.StatusCode < 400 {
+		return "", resp
+	}
+
+	for _, code := range t.retry.StatusCodes {
+		if resp.StatusCode == code && (idempotent || code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable) {
+			return resp.Status, resp
+		}
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return "", resp
+	}
+	for _, msg := range t.retry.Errors {
+		if msg != "" && strings.Contains(string(b), msg) {
+			return fmt.Sprintf("%s: %s", resp.Status, msg), resp
+		}
+	}
+	return "", resp
+}
+
+// isAuthRequest returns whether the request is one of the login requests,
+// which are not retried after logging in again.
+func isAuthRequest(req *http.Request) bool {
+	return strings.Contains(req.URL.Path, "/auth/")
+}
+
+// login starts a new session on the array of the request, and returns its
+// cookies. The API token is first retrieved with the username and password
+// if it is not configured.
+func (t *retryTransport) login(req *http.Request) ([]*http.Cookie, error) {
+	// The requests are sent to https://<target>/api/<version>/<path>.
+	parts := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/"), "/", 3)
+	if len(parts) < 3 || parts[0] != "api" {
+		return nil, fmt.Errorf("session expired and can not be renewed for %s", req.URL)
+	}
+	base := fmt.Sprintf("%s://%s/api/%s/", req.URL.Scheme, req.URL.Host, parts[1])
+
+	t.mu.Lock()
+	token := t.apiToken
+	t.mu.Unlock()
+	if token == "" {
+		var v struct {
+			Token string `json:"api_token"`
+		}
+		if _, err := t.post(req, base+"auth/apitoken", map[string]string{"username": t.username, "password": t.password}, &v); err != nil {
+			return nil, fmt.Errorf("session expired and the API token could not be retrieved: %s", err)
+		}
+		token = v.Token
+		t.mu.Lock()
+		t.apiToken = token
+		t.mu.Unlock()
+	}
+
+	resp, err := t.post(req, base+"auth/session", map[string]string{"api_token": token}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("session expired and could not be renewed: %s", err)
+	}
+	return resp.Cookies(), nil
+}
+
+// post sends a login request, and decodes its response into v if not nil.
+func (t *retryTransport) post(req *http.Request, url string, data interface{}, v interface{}) (*http.Response, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	r, err := http.NewRequestWithContext(req.Context(), http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "application/json")
+	if ua := req.Header.Get("User-Agent"); ua != "" {
+		r.Header.Set("User-Agent", ua)
+	}
+
+	resp, err := t.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", resp.Status, body)
+	}
+	if v != nil {
+		if err := json.Unmarshal(body, v); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
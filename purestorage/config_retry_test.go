@@ -0,0 +1,256 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryClient(fa *fakeFlashArray, c *Config) *Config {
+	c.Target = fa.Target()
+	c.Retry = &RetryConfig{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  2 * time.Millisecond,
+		StatusCodes: defaultRetryConfig.StatusCodes,
+		Errors:      defaultRetryConfig.Errors,
+	}
+	return c
+}
+
+func TestConfigClient_retry(t *testing.T) {
+	fa := newFakeFlashArray()
+	defer fa.Close()
+
+	client, err := testRetryClient(fa, &Config{APIToken: fa.APIToken}).Client()
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+
+	tests := []struct {
+		name      string
+		failures  []fakeFailure
+		err       string
+		remaining int
+	}{
+		{
+			name: "transient errors",
+			failures: []fakeFailure{
+				{http.StatusServiceUnavailable, "Service unavailable."},
+				{http.StatusBadRequest, "Could not execute the command, the system is busy."},
+			},
+		},
+		{
+			name: "max attempts",
+			failures: []fakeFailure{
+				{http.StatusInternalServerError, "Internal error."},
+				{http.StatusInternalServerError, "Internal error."},
+				{http.StatusInternalServerError, "Internal error."},
+				{http.StatusInternalServerError, "Internal error."},
+			},
+			err:       "Internal error.",
+			remaining: 1,
+		},
+		{
+			name: "not retryable",
+			failures: []fakeFailure{
+				{http.StatusBadRequest, "Volume does not exist."},
+				{http.StatusBadRequest, "Volume does not exist."},
+			},
+			err:       "Volume does not exist.",
+			remaining: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fa.fail(tt.failures...)
+			_, err := client.Array.Get(nil)
+			remaining := len(fa.failures)
+			fa.failures = nil
+			if tt.err == "" && err != nil {
+				t.Fatalf("error getting array: %s", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Fatalf("expected error %q, got %v", tt.err, err)
+			}
+			if remaining != tt.remaining {
+				t.Fatalf("expected %d failures left, got %d", tt.remaining, remaining)
+			}
+		})
+	}
+}
+
+// Only retry the requests that are not idempotent when the array did not
+// execute them.
+func TestConfigClient_retryNotIdempotent(t *testing.T) {
+	fa := newFakeFlashArray()
+	defer fa.Close()
+
+	client, err := testRetryClient(fa, &Config{APIToken: fa.APIToken}).Client()
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+
+	fa.fail(fakeFailure{http.StatusInternalServerError, "Internal error."})
+	if _, err := client.Volumes.CreateVolume("tfretryvolume", 1048576); err == nil || !strings.Contains(err.Error(), "Internal error.") {
+		t.Fatalf("expected the create to fail, got %v", err)
+	}
+	if _, ok := fa.volumes["tfretryvolume"]; ok {
+		t.Fatalf("create was retried after a server error")
+	}
+
+	fa.fail(fakeFailure{http.StatusTooManyRequests, "Too many requests."}, fakeFailure{http.StatusServiceUnavailable, "Service unavailable."})
+	if _, err := client.Volumes.CreateVolume("tfretryvolume", 1048576); err != nil {
+		t.Fatalf("error creating volume: %s", err)
+	}
+}
+
+// A request is sent to a server that resets the connection: a GET is sent
+// again, a POST is not.
+func TestRetryTransport_connectionReset(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("error hijacking connection: %s", err)
+			return
+		}
+		conn.(*net.TCPConn).SetLinger(0)
+		conn.Close()
+	}))
+	defer server.Close()
+
+	hc := &http.Client{Transport: &retryTransport{
+		transport: &http.Transport{DisableKeepAlives: true},
+		retry:     RetryConfig{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+	}}
+
+	for method, want := range map[string]int32{http.MethodGet: 3, http.MethodPost: 1} {
+		atomic.StoreInt32(&requests, 0)
+		req, err := http.NewRequest(method, server.URL+"/api/1.16/volume/tfretryvolume", strings.NewReader("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := hc.Do(req); err == nil {
+			t.Fatalf("%s: expected a connection error", method)
+		}
+		if n := atomic.LoadInt32(&requests); n != want {
+			t.Fatalf("%s: expected %d requests, got %d", method, want, n)
+		}
+	}
+}
+
+func TestConfigClient_relogin(t *testing.T) {
+	fa := newFakeFlashArray()
+	defer fa.Close()
+
+	configs := map[string]*Config{
+		"api_token":         {APIToken: fa.APIToken},
+		"username_password": {Username: fa.Username, Password: fa.Password},
+	}
+	for name, c := range configs {
+		t.Run(name, func(t *testing.T) {
+			client, err := testRetryClient(fa, c).Client()
+			if err != nil {
+				t.Fatalf("error creating client: %s", err)
+			}
+
+			fa.expireSessions()
+			if _, err := client.Array.Get(nil); err != nil {
+				t.Fatalf("error getting array after the session expired: %s", err)
+			}
+			if _, err := client.Volumes.ListVolumes(nil); err != nil {
+				t.Fatalf("error listing volumes: %s", err)
+			}
+			// The second request must use the new session instead of
+			// logging in again.
+			if len(fa.sessions) != 1 {
+				t.Fatalf("expected 1 session, got %d", len(fa.sessions))
+			}
+		})
+	}
+}
+
+func TestConfigClient_reloginFailed(t *testing.T) {
+	fa := newFakeFlashArray()
+	defer fa.Close()
+
+	client, err := testRetryClient(fa, &Config{APIToken: fa.APIToken}).Client()
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+
+	fa.expireSessions()
+	fa.admins[fa.Username].Token = ""
+	_, err = client.Array.Get(nil)
+	if err == nil || !strings.Contains(err.Error(), "session expired and could not be renewed") {
+		t.Fatalf("expected the login to fail, got %v", err)
+	}
+}
+
+func TestRetryConfig_backoff(t *testing.T) {
+	r := &RetryConfig{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for retry, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		for i := 0; i < 20; i++ {
+			d := r.backoff(retry + 1)
+			if d < max/2 || d > max {
+				t.Fatalf("backoff of retry %d must be between %s and %s, got %s", retry+1, max/2, max, d)
+			}
+		}
+	}
+
+	r = &RetryConfig{}
+	if d := r.backoff(1); d != 0 {
+		t.Fatalf("expected no backoff, got %s", d)
+	}
+}
+
+func Test_expandRetryConfig(t *testing.T) {
+	retry := map[string]interface{}{
+		"max_attempts":           2,
+		"min_backoff":            "500ms",
+		"max_backoff":            "10s",
+		"retryable_status_codes": []interface{}{503},
+		"retryable_errors":       []interface{}{},
+	}
+	r, err := expandRetryConfig([]interface{}{retry})
+	if err != nil {
+		t.Fatalf("error expanding retry: %s", err)
+	}
+	if r.MaxAttempts != 2 || r.MinBackoff != 500*time.Millisecond || r.MaxBackoff != 10*time.Second {
+		t.Fatalf("unexpected retry policy %#v", r)
+	}
+	if len(r.StatusCodes) != 1 || r.StatusCodes[0] != 503 || len(r.Errors) != 1 || r.Errors[0] != "Could not execute" {
+		t.Fatalf("unexpected retryable responses %#v", r)
+	}
+
+	retry["min_backoff"] = "1m"
+	if _, err := expandRetryConfig([]interface{}{retry}); err == nil || !strings.Contains(err.Error(), "max_backoff can not be shorter") {
+		t.Fatalf("expected a backoff error, got %v", err)
+	}
+
+	if _, errs := validateDuration("10", "min_backoff"); len(errs) == 0 {
+		t.Fatalf("expected a duration error")
+	}
+}
@@ -17,8 +17,11 @@
 package purestorage
 
 import (
+	"encoding/pem"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -152,3 +155,243 @@ func TestNewConfigWithAllAuth(t *testing.T) {
 		t.Fatalf("error NOT generated when username, password, and api_token provided.")
 	}
 }
+
+func testProfilesFile(t *testing.T) string {
+	dir := t.TempDir()
+	token := filepath.Join(dir, "prod.token")
+	if err := os.WriteFile(token, []byte("prodtoken\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	ca := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(ca, []byte{}, 0600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config")
+	profiles := `# FlashArray profiles
+[prod]
+target         = prod.flasharray
+api_token_file = ` + token + `
+ca_bundle      = ` + ca + `
+rest_version   = 1.17
+
+[lab]
+target    = "lab.flasharray"
+api_token = labtoken
+`
+	if err := os.WriteFile(path, []byte(profiles), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testProfileConfig(t *testing.T, raw map[string]string, env map[string]string) (*Config, error) {
+	for _, k := range []string{"PURE_TARGET", "PURE_APITOKEN", "PURE_USERNAME", "PURE_PASSWORD"} {
+		t.Setenv(k, env[k])
+	}
+	r := &schema.Resource{Schema: Provider().Schema}
+	d := r.Data(nil)
+	for k, v := range raw {
+		d.Set(k, v)
+	}
+	return NewConfig(d)
+}
+
+func TestNewConfigWithProfile(t *testing.T) {
+	path := testProfilesFile(t)
+
+	c, err := testProfileConfig(t, map[string]string{"profiles_file": path, "profile": "prod"}, nil)
+	if err != nil {
+		t.Fatalf("error creating new configuration: %s", err)
+	}
+	if c.Target != "prod.flasharray" || c.APIToken != "prodtoken" || c.RestVersion != "1.17" {
+		t.Fatalf("profile prod not applied: %#v", c)
+	}
+	if c.TLS == nil || c.TLS.CAFile != filepath.Join(filepath.Dir(path), "ca.pem") {
+		t.Fatalf("expected the ca_bundle of profile prod, got %#v", c.TLS)
+	}
+
+	c, err = testProfileConfig(t, map[string]string{"profiles_file": path, "profile": "lab", "target": "lab.flasharray"}, nil)
+	if err != nil {
+		t.Fatalf("error creating new configuration: %s", err)
+	}
+	if c.Target != "lab.flasharray" || c.APIToken != "labtoken" || c.TLS != nil {
+		t.Fatalf("profile lab not applied: %#v", c)
+	}
+}
+
+func TestNewConfigWithProfileErrors(t *testing.T) {
+	path := testProfilesFile(t)
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		raw  map[string]string
+		err  string
+	}{
+		{
+			name: "missing profile",
+			raw:  map[string]string{"profile": "dev"},
+			err:  "profile 'dev' is not defined in " + path,
+		},
+		{
+			name: "missing file",
+			raw:  map[string]string{"profile": "prod", "profiles_file": path + ".missing"},
+			err:  "profile 'prod' can not be read",
+		},
+		{
+			name: "target argument",
+			raw:  map[string]string{"profile": "prod", "target": "other.flasharray"},
+			err:  "target is set by both profile 'prod' and the target provider argument",
+		},
+		{
+			name: "target environment variable",
+			env:  map[string]string{"PURE_TARGET": "other.flasharray"},
+			raw:  map[string]string{"profile": "prod", "target": "other.flasharray"},
+			err:  "target is set by both profile 'prod' and the PURE_TARGET environment variable",
+		},
+		{
+			name: "api token environment variable",
+			env:  map[string]string{"PURE_APITOKEN": "othertoken"},
+			raw:  map[string]string{"profile": "lab", "api_token": "othertoken"},
+			err:  "api_token is set by both profile 'lab' and the PURE_APITOKEN environment variable",
+		},
+		{
+			name: "rest version argument",
+			raw:  map[string]string{"profile": "prod", "rest_version": "1.16"},
+			err:  "rest_version is set by both profile 'prod' and the rest_version provider argument",
+		},
+		{
+			name: "username",
+			raw:  map[string]string{"profile": "prod", "username": "pureuser", "password": "pureuser"},
+			err:  "profile 'prod' sets an API token, which can not be used with the username provider argument",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := tt.raw["profiles_file"]; !ok {
+				tt.raw["profiles_file"] = path
+			}
+			_, err := testProfileConfig(t, tt.raw, tt.env)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// Verify the certificate of the fake array with the ca_bundle of a profile.
+func TestConfigClient_profileCABundle(t *testing.T) {
+	fa := newFakeFlashArray()
+	defer fa.Close()
+
+	dir := t.TempDir()
+	ca := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(ca, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: fa.server.Certificate().Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	otherCA := filepath.Join(dir, "other-ca.pem")
+	cert, _ := testTLSClientCert(t)
+	if err := os.WriteFile(otherCA, []byte(cert), 0600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config")
+	profiles := `[fake]
+target    = ` + fa.Target() + `
+api_token = ` + fa.APIToken + `
+ca_bundle = ` + ca + `
+
+[other]
+target    = ` + fa.Target() + `
+api_token = ` + fa.APIToken + `
+ca_bundle = ` + otherCA + `
+`
+	if err := os.WriteFile(path, []byte(profiles), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := testProfileConfig(t, map[string]string{"profiles_file": path, "profile": "fake"}, nil)
+	if err != nil {
+		t.Fatalf("error creating new configuration: %s", err)
+	}
+	if _, err := c.Client(); err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+
+	c, err = testProfileConfig(t, map[string]string{"profiles_file": path, "profile": "other"}, nil)
+	if err != nil {
+		t.Fatalf("error creating new configuration: %s", err)
+	}
+	if _, err := c.Client(); err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Fatalf("expected a certificate error, got %v", err)
+	}
+}
+
+func Test_loadProfile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		profiles string
+		err      string
+	}{
+		{"unknown setting", "[prod]\nusername = pureuser\n", "unknown setting 'username' in profile 'prod'"},
+		{"no value", "[prod]\ntarget\n", "expected key = value"},
+		{"unclosed section", "[prod\ntarget = prod\n", "section header is not closed"},
+		{"duplicate", "[prod]\ntarget = a\n[prod]\ntarget = b\n", "profile 'prod' is defined more than once"},
+		{"token and token file", "[prod]\napi_token = a\napi_token_file = b\n", "sets both api_token and api_token_file"},
+		{"token file", "[prod]\napi_token_file = " + filepath.Join(dir, "missing") + "\n", "api_token_file can not be read"},
+		{"ca bundle", "[prod]\nca_bundle = " + filepath.Join(dir, "missing") + "\n", "ca_bundle can not be read"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.Repeat("x", i+1))
+			if err := os.WriteFile(path, []byte(tt.profiles), 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := loadProfile(path, "prod")
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// Connect to the fake array with the API token, or the username and
+// password, and negotiate the REST API version.
+func TestConfigClient_connect(t *testing.T) {
+	fa := newFakeFlashArray()
+	defer fa.Close()
+
+	tests := []struct {
+		name    string
+		config  Config
+		version string
+		err     string
+	}{
+		{name: "api_token", config: Config{APIToken: fa.APIToken}, version: "1.16"},
+		{name: "username", config: Config{Username: fa.Username, Password: fa.Password}, version: "1.16"},
+		{name: "rest_version", config: Config{APIToken: fa.APIToken, RestVersion: "1.14"}, version: "1.14"},
+		{name: "unsupported rest_version", config: Config{APIToken: fa.APIToken, RestVersion: "1.17"}, err: "incompatible with REST API version 1.17"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.config
+			c.Target = fa.Target()
+			client, err := c.Client()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error creating client: %s", err)
+			}
+			if client.RestVersion != tt.version || client.APIToken != fa.APIToken {
+				t.Fatalf("unexpected client: %s %s", client.RestVersion, client.APIToken)
+			}
+			if _, err := client.Array.Get(nil); err != nil {
+				t.Fatalf("error getting the array: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TLSConfig holds the TLS settings used to connect to the array API.
+type TLSConfig struct {
+	CAFile             string
+	CAPEM              string
+	InsecureSkipVerify bool
+	ServerName         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	ClientCertPEM      string
+	ClientKeyPEM       string
+	SHA256Fingerprint  string
+}
+
+// tlsSchema returns the schema of the tls block of the provider and of the
+// arrays block.
+func tlsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "TLS settings used to connect to the array.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ca_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Path of a PEM bundle of the CAs to verify the array certificate with.",
+				},
+				"ca_pem": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "PEM bundle of the CAs to verify the array certificate with.",
+				},
+				"insecure_skip_verify": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Do not verify the array certificate. The sha256_fingerprint is still checked.",
+				},
+				"server_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name to verify the array certificate for, instead of the target.",
+				},
+				"client_cert_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Path of the PEM client certificate to present to the array.",
+				},
+				"client_key_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Path of the PEM key of the client certificate.",
+				},
+				"client_cert_pem": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "PEM client certificate to present to the array.",
+				},
+				"client_key_pem": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "PEM key of the client certificate.",
+				},
+				"sha256_fingerprint": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "SHA-256 fingerprint the array certificate must have, in hex with optional colons.",
+				},
+			},
+		},
+	}
+}
+
+// expandTLSConfig returns the TLSConfig of a tls block, or nil if the block
+// is not set.
+func expandTLSConfig(l []interface{}) (*TLSConfig, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+	m := l[0].(map[string]interface{})
+	t := &TLSConfig{
+		CAFile:             m["ca_file"].(string),
+		CAPEM:              m["ca_pem"].(string),
+		InsecureSkipVerify: m["insecure_skip_verify"].(bool),
+		ServerName:         m["server_name"].(string),
+		ClientCertFile:     m["client_cert_file"].(string),
+		ClientKeyFile:      m["client_key_file"].(string),
+		ClientCertPEM:      m["client_cert_pem"].(string),
+		ClientKeyPEM:       m["client_key_pem"].(string),
+		SHA256Fingerprint:  m["sha256_fingerprint"].(string),
+	}
+
+	if t.CAFile != "" && t.CAPEM != "" {
+		return nil, fmt.Errorf("tls: only one of ca_file and ca_pem can be set")
+	}
+	if (t.ClientCertFile != "" || t.ClientKeyFile != "") && (t.ClientCertPEM != "" || t.ClientKeyPEM != "") {
+		return nil, fmt.Errorf("tls: client_cert_file and client_key_file can not be used with client_cert_pem and client_key_pem")
+	}
+	if (t.ClientCertFile == "") != (t.ClientKeyFile == "") {
+		return nil, fmt.Errorf("tls: client_cert_file and client_key_file must be set together")
+	}
+	if (t.ClientCertPEM == "") != (t.ClientKeyPEM == "") {
+		return nil, fmt.Errorf("tls: client_cert_pem and client_key_pem must be set together")
+	}
+	return t, nil
+}
+
+// tlsConfig returns the crypto/tls configuration of the TLSConfig.
+func (t *TLSConfig) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+		ServerName:         t.ServerName,
+	}
+
+	ca := []byte(t.CAPEM)
+	if t.CAFile != "" {
+		b, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: ca_file can not be read: %s", err)
+		}
+		ca = b
+	}
+	if len(ca) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("tls: no PEM certificates found in the CA bundle")
+		}
+		config.RootCAs = pool
+	}
+
+	switch {
+	case t.ClientCertFile != "":
+		cert, err := tls.LoadX509KeyPair(t.ClientCertFile, t.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: client certificate can not be loaded: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	case t.ClientCertPEM != "":
+		cert, err := tls.X509KeyPair([]byte(t.ClientCertPEM), []byte(t.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("tls: client certificate can not be loaded: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if t.SHA256Fingerprint != "" {
+		fingerprint, err := parseFingerprint(t.SHA256Fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		// VerifyConnection is called after the certificate chain is
+		// verified, and also when the verification is skipped.
+		config.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return fmt.Errorf("tls: the array did not present a certificate")
+			}
+			actual := sha256.Sum256(cs.PeerCertificates[0].Raw)
+			if !bytes.Equal(actual[:], fingerprint) {
+				return fmt.Errorf("tls: the array certificate has SHA-256 fingerprint %s, expected %s", formatFingerprint(actual[:]), formatFingerprint(fingerprint))
+			}
+			return nil
+		}
+	}
+	return config, nil
+}
+
+// parseFingerprint parses a SHA-256 fingerprint in hex, with or without
+// colons between the bytes.
+func parseFingerprint(s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(s), ":", ""))
+	if err != nil || len(b) != sha256.Size {
+		return nil, fmt.Errorf("tls: sha256_fingerprint must be %d bytes in hex, got '%s'", sha256.Size, s)
+	}
+	return b, nil
+}
+
+// formatFingerprint formats a fingerprint as colon separated upper case hex,
+// as the array and openssl show it.
+func formatFingerprint(b []byte) string {
+	s := make([]string, len(b))
+	for i, c := range b {
+		s[i] = fmt.Sprintf("%02X", c)
+	}
+	return strings.Join(s, ":")
+}
+
+// transport returns the http transport of the clients of the Config. Without
+// a tls block the array certificate is not verified, as with the pugo sdk;
+// the deprecated verify_https is ignored. The requests are retried with the
+// retry policy of the Config, or the default one.
+func (c *Config) transport() (http.RoundTripper, error) {
+	config := &tls.Config{InsecureSkipVerify: true}
+	if c.TLS != nil {
+		var err error
+		if config, err = c.TLS.tlsConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	retry := defaultRetryConfig
+	if c.Retry != nil {
+		retry = *c.Retry
+	}
+	return &retryTransport{
+		transport: &http.Transport{TLSClientConfig: config},
+		retry:     retry,
+		username:  c.Username,
+		password:  c.Password,
+		apiToken:  c.APIToken,
+	}, nil
+}
@@ -0,0 +1,163 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testTLSClientCert(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestConfigClient_tls(t *testing.T) {
+	fa := newFakeFlashArray()
+	defer fa.Close()
+
+	cert := fa.server.Certificate()
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := formatFingerprint(sum[:])
+
+	tests := []struct {
+		name        string
+		verifyHTTPS bool
+		tls         *TLSConfig
+		err         string
+	}{
+		{name: "no verification"},
+		{name: "verify_https is ignored", verifyHTTPS: true},
+		{name: "system CAs", tls: &TLSConfig{}, err: "certificate"},
+		{name: "ca_pem", tls: &TLSConfig{CAPEM: ca}},
+		{name: "server_name", tls: &TLSConfig{CAPEM: ca, ServerName: "example.com"}},
+		{name: "wrong server_name", tls: &TLSConfig{CAPEM: ca, ServerName: "array.invalid"}, err: "array.invalid"},
+		{name: "fingerprint", tls: &TLSConfig{InsecureSkipVerify: true, SHA256Fingerprint: strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))}},
+		{name: "fingerprint and ca_pem", tls: &TLSConfig{CAPEM: ca, SHA256Fingerprint: fingerprint}},
+		{name: "wrong fingerprint", tls: &TLSConfig{InsecureSkipVerify: true, SHA256Fingerprint: strings.Repeat("00:", 31) + "00"}, err: "expected " + strings.Repeat("00:", 31) + "00"},
+		{name: "invalid fingerprint", tls: &TLSConfig{InsecureSkipVerify: true, SHA256Fingerprint: "abc"}, err: "sha256_fingerprint must be 32 bytes"},
+		{name: "invalid ca_pem", tls: &TLSConfig{CAPEM: "ca"}, err: "no PEM certificates found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{Target: fa.Target(), APIToken: fa.APIToken, VerifyHTTPS: tt.verifyHTTPS, TLS: tt.tls}
+			_, err := c.Client()
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("error creating client: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestConfigTransport_clientCert(t *testing.T) {
+	var subject string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			subject = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	certPEM, keyPEM := testTLSClientCert(t)
+	c := &Config{TLS: &TLSConfig{InsecureSkipVerify: true, ClientCertPEM: certPEM, ClientKeyPEM: keyPEM}}
+	transport, err := c.transport()
+	if err != nil {
+		t.Fatalf("error creating transport: %s", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("error sending request: %s", err)
+	}
+	resp.Body.Close()
+	if subject != "terraform" {
+		t.Fatalf("expected the client certificate to be presented, got subject %q", subject)
+	}
+}
+
+func Test_expandTLSConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		tls  map[string]interface{}
+		err  string
+	}{
+		{"ca", map[string]interface{}{"ca_file": "ca.pem", "ca_pem": "pem"}, "only one of ca_file and ca_pem"},
+		{"client cert file", map[string]interface{}{"client_cert_file": "cert.pem"}, "client_cert_file and client_key_file must be set together"},
+		{"client key pem", map[string]interface{}{"client_key_pem": "key"}, "client_cert_pem and client_key_pem must be set together"},
+		{"client cert", map[string]interface{}{"client_cert_file": "cert.pem", "client_key_file": "key.pem", "client_cert_pem": "cert"}, "can not be used with"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := map[string]interface{}{
+				"insecure_skip_verify": false,
+			}
+			for _, k := range []string{"ca_file", "ca_pem", "server_name", "client_cert_file", "client_key_file", "client_cert_pem", "client_key_pem", "sha256_fingerprint"} {
+				m[k] = ""
+			}
+			for k, v := range tt.tls {
+				m[k] = v
+			}
+			_, err := expandTLSConfig([]interface{}{m})
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got %v", tt.err, err)
+			}
+		})
+	}
+
+	if c, err := expandTLSConfig(nil); c != nil || err != nil {
+		t.Fatalf("expected no TLSConfig without a tls block, got %#v, %v", c, err)
+	}
+}
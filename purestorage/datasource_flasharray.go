@@ -41,6 +41,101 @@ func dataSourcePureFlashArray() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"capacity": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Usable capacity of the array in bytes.",
+			},
+			"used": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Physical space used on the array in bytes.",
+			},
+			"free": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Physical space free on the array in bytes.",
+			},
+			"volumes_space": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Physical space used by the data of the volumes in bytes.",
+			},
+			"snapshots_space": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Physical space used by the data of the snapshots in bytes.",
+			},
+			"shared_space": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Physical space used by deduplicated data shared between volumes and snapshots in bytes.",
+			},
+			"system_space": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Physical space used by the array itself in bytes.",
+			},
+			"data_reduction": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Ratio of the data written to the volumes to the physical space it uses, not counting thin provisioning.",
+			},
+			"total_reduction": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Ratio of the provisioned size of the volumes to the physical space they use.",
+			},
+			"thin_provisioning": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Fraction of the provisioned size of the volumes that is not written.",
+			},
+			"performance": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Current performance of the array.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"reads_per_sec": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"writes_per_sec": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"input_per_sec": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"output_per_sec": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"usec_per_read_op": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"usec_per_write_op": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"san_usec_per_read_op": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"san_usec_per_write_op": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"queue_depth": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -53,9 +148,38 @@ func dataSourcePureFlashArrayRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	space, err := client.Array.GetArraySpace(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(space) != 1 {
+		return diag.Errorf("expected the space of one array, got %d", len(space))
+	}
+
+	monitor, err := client.Array.GetArrayMonitor(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(monitor) != 1 {
+		return diag.Errorf("expected the performance of one array, got %d", len(monitor))
+	}
+
 	d.SetId(flasharray.ID)
 	d.Set("name", flasharray.ArrayName)
 	d.Set("version", flasharray.Version)
 	d.Set("revision", flasharray.Revision)
+	d.Set("capacity", space[0].Capacity)
+	d.Set("used", space[0].Total)
+	d.Set("free", space[0].Capacity-space[0].Total)
+	d.Set("volumes_space", space[0].Volumes)
+	d.Set("snapshots_space", space[0].Snapshots)
+	d.Set("shared_space", space[0].SharedSpace)
+	d.Set("system_space", space[0].System)
+	d.Set("data_reduction", space[0].DataReduction)
+	d.Set("total_reduction", space[0].TotalReduction)
+	d.Set("thin_provisioning", space[0].ThinProvisioning)
+	if err := d.Set("performance", flattenArrayPerformance(monitor[0])); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
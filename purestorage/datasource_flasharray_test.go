@@ -0,0 +1,87 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Read the space and performance of the array
+func TestAccDataSourcePureFlashArray_read(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureFlashArrayDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.purefa_flasharray.tfflasharraytest", "name"),
+					resource.TestCheckResourceAttrSet("data.purefa_flasharray.tfflasharraytest", "capacity"),
+					resource.TestCheckResourceAttrSet("data.purefa_flasharray.tfflasharraytest", "free"),
+					resource.TestCheckResourceAttrSet("data.purefa_flasharray.tfflasharraytest", "data_reduction"),
+					resource.TestCheckResourceAttr("data.purefa_flasharray.tfflasharraytest", "performance.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// Read the space and performance of the fake array.
+func TestDataSourcePureFlashArray_read(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.volumes["tfflasharrayvolume"] = &fakeVolume{Name: "tfflasharrayvolume", Size: 4 * 1024 * 1024 * 1024}
+
+	d := testFakeRead(t, dataSourcePureFlashArray(), map[string]interface{}{}, client)
+	gib := 1024 * 1024 * 1024
+	if d.Id() != fa.ArrayID || d.Get("name") != "fakearray" || d.Get("version") != "5.3.2" {
+		t.Fatalf("unexpected array: %s %v %v", d.Id(), d.Get("name"), d.Get("version"))
+	}
+	if d.Get("capacity") != fakeCapacity || d.Get("volumes_space") != gib || d.Get("used") != 2*gib || d.Get("free") != fakeCapacity-2*gib {
+		t.Fatalf("unexpected space: capacity %v, volumes %v, used %v, free %v", d.Get("capacity"), d.Get("volumes_space"), d.Get("used"), d.Get("free"))
+	}
+	if d.Get("data_reduction") != 4.0 || d.Get("total_reduction") != 8.0 {
+		t.Fatalf("unexpected reduction: %v %v", d.Get("data_reduction"), d.Get("total_reduction"))
+	}
+	if d.Get("performance.0.reads_per_sec") != 1200 || d.Get("performance.0.usec_per_write_op") != 180 {
+		t.Fatalf("unexpected performance: %v", d.Get("performance"))
+	}
+}
+
+const testAccCheckPureFlashArrayDataSourceConfig = `
+data "purefa_flasharray" "tfflasharraytest" {
+}`
+
+// The deprecated purefa_flasharray resource only reads the array, and
+// destroying it leaves the array as it is.
+func TestResourcePureFlashArrayShim_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureFlashArrayShim()
+	if r.DeprecationMessage == "" {
+		t.Fatalf("purefa_flasharray resource is not deprecated")
+	}
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{}, client)
+	if state.ID != fa.ArrayID || state.Attributes["name"] != "fakearray" {
+		t.Fatalf("unexpected state: %v", state)
+	}
+	testFakeDestroy(t, r, state, client)
+	if fa.ArrayName != "fakearray" {
+		t.Fatalf("array was changed by destroy: %s", fa.ArrayName)
+	}
+}
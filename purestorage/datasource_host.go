@@ -0,0 +1,65 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePureHost() *schema.Resource {
+	s := dataSourceSchemaFromResource(resourcePureHost().Schema)
+	s["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Name of the host",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourcePureHostRead,
+		Schema:      s,
+	}
+}
+
+func dataSourcePureHostRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	name := d.Get("name").(string)
+	host, _ := client.Hosts.GetHost(name, nil)
+	if host == nil {
+		return diag.Errorf("host '%s' not found", name)
+	}
+
+	d.SetId(host.Name)
+	if diags := resourcePureHostRead(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	// The host only reads the connections of its volume block and the
+	// host group set on it.
+	d.Set("hgroup", host.Hgroup)
+	volumes, err := client.Hosts.ListHostConnections(host.Name, map[string]string{"private": "true"})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("volume", flattenVolume(volumes)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Read a host created outside of the data source
+func TestAccDataSourcePureHost_read(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureHostDataSourceConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.purefa_host.tfhosttest", "name", fmt.Sprintf("tfhosttest%d", rInt)),
+					resource.TestCheckResourceAttr("data.purefa_host.tfhosttest", "volume.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// Read a host of the fake array.
+func TestDataSourcePureHost_read(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.volumes["tfhostvolume"] = &fakeVolume{Name: "tfhostvolume", Size: 1024000000}
+	fa.hgroups["tfhostgroup"] = &fakeHgroup{Name: "tfhostgroup", Hosts: []string{"tfhosttest"}, Volumes: map[string]int{}}
+	fa.hosts["tfhosttest"] = &fakeHost{
+		Name:        "tfhosttest",
+		Iqn:         []string{"iqn.2016-04.com.example:tfhosttest"},
+		Personality: "esxi",
+		Hgroup:      "tfhostgroup",
+		Volumes:     map[string]int{"tfhostvolume": 3},
+	}
+
+	d := testFakeRead(t, dataSourcePureHost(), map[string]interface{}{"name": "tfhosttest"}, client)
+	if d.Id() != "tfhosttest" || d.Get("personality") != "esxi" || d.Get("hgroup") != "tfhostgroup" || d.Get("iqn.#") != 1 {
+		t.Fatalf("unexpected host: %s %v %v %v", d.Id(), d.Get("personality"), d.Get("hgroup"), d.Get("iqn"))
+	}
+	volumes := d.Get("volume").(*schema.Set).List()
+	if len(volumes) != 1 || volumes[0].(map[string]interface{})["vol"] != "tfhostvolume" || volumes[0].(map[string]interface{})["lun"] != 3 {
+		t.Fatalf("unexpected volumes: %v", volumes)
+	}
+
+	testFakeReadError(t, dataSourcePureHost(), map[string]interface{}{"name": "tfhostmissing"}, client)
+}
+
+func testAccCheckPureHostDataSourceConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_volume" "tfhosttest" {
+	name = "tfhosttest-volume-%d"
+	size = 1024000000
+}
+
+resource "purefa_host" "tfhosttest" {
+	name = "tfhosttest%d"
+	volume {
+		vol = purefa_volume.tfhosttest.name
+		lun = 1
+	}
+}
+
+data "purefa_host" "tfhosttest" {
+	name = purefa_host.tfhosttest.name
+}`, rInt, rInt)
+}
+
+// The CHAP passwords are sensitive in the resource and the data source.
+func TestDataSourcePureHost_sensitive(t *testing.T) {
+	for name, s := range map[string]map[string]*schema.Schema{
+		"resource":    resourcePureHost().Schema,
+		"data source": dataSourcePureHost().Schema,
+	} {
+		for _, k := range []string{"host_password", "target_password"} {
+			if !s[k].Sensitive {
+				t.Errorf("%s of the %s is not sensitive", k, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Read a host group created outside of the data source
+func TestAccDataSourcePureHostgroup_read(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureHostgroupDataSourceConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.purefa_hostgroup.tfhostgrouptest", "name", fmt.Sprintf("tfhostgrouptest%d", rInt)),
+					resource.TestCheckResourceAttr("data.purefa_hostgroup.tfhostgrouptest", "hosts.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// Read a host group of the fake array.
+func TestDataSourcePureHostgroup_read(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.volumes["tfhostgroupvolume"] = &fakeVolume{Name: "tfhostgroupvolume", Size: 1024000000}
+	fa.hosts["tfhostgrouphost"] = &fakeHost{Name: "tfhostgrouphost", Hgroup: "tfhostgrouptest", Volumes: map[string]int{}}
+	fa.hgroups["tfhostgrouptest"] = &fakeHgroup{Name: "tfhostgrouptest", Hosts: []string{"tfhostgrouphost"}, Volumes: map[string]int{"tfhostgroupvolume": 254}}
+
+	d := testFakeRead(t, dataSourcePureHostgroup(), map[string]interface{}{"name": "tfhostgrouptest"}, client)
+	if d.Id() != "tfhostgrouptest" || fmt.Sprint(d.Get("hosts").(*schema.Set).List()) != "[tfhostgrouphost]" {
+		t.Fatalf("unexpected host group: %s %v", d.Id(), d.Get("hosts").(*schema.Set).List())
+	}
+	volumes := d.Get("volume").(*schema.Set).List()
+	if len(volumes) != 1 || volumes[0].(map[string]interface{})["lun"] != 254 {
+		t.Fatalf("unexpected volumes: %v", volumes)
+	}
+
+	testFakeReadError(t, dataSourcePureHostgroup(), map[string]interface{}{"name": "tfhostgroupmissing"}, client)
+}
+
+func testAccCheckPureHostgroupDataSourceConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_host" "tfhostgrouptest" {
+	name = "tfhostgrouptesthost%d"
+}
+
+resource "purefa_hostgroup" "tfhostgrouptest" {
+	name  = "tfhostgrouptest%d"
+	hosts = [purefa_host.tfhostgrouptest.name]
+}
+
+data "purefa_hostgroup" "tfhostgrouptest" {
+	name = purefa_hostgroup.tfhostgrouptest.name
+}`, rInt, rInt)
+}
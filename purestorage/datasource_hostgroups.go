@@ -0,0 +1,93 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePureHostgroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePureHostgroupsRead,
+
+		Schema: nameFilterSchema(map[string]*schema.Schema{
+			"host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the host group this host is a member of.",
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"hostgroups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hosts": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourcePureHostgroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	match, err := nameFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hgroups, err := client.Hostgroups.ListHostgroups(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var out []flasharray.Hostgroup
+	var names []string
+	for _, hg := range hgroups {
+		switch {
+		case !match(hg.Name):
+		case d.Get("host").(string) != "" && !stringInSlice(d.Get("host").(string), hg.Hosts):
+		default:
+			out = append(out, hg)
+			names = append(names, hg.Name)
+		}
+	}
+
+	d.SetId(client.Target)
+	d.Set("names", names)
+	if err := d.Set("hostgroups", flattenHostgroups(out)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// List the host group of a host
+func TestAccDataSourcePureHostgroups_read(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureHostgroupsDataSourceConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.purefa_hostgroups.tfhostgroupstest", "hostgroups.#", "1"),
+					resource.TestCheckResourceAttr("data.purefa_hostgroups.tfhostgroupstest", "hostgroups.0.name", fmt.Sprintf("tfhostgroupstest%d", rInt)),
+					resource.TestCheckResourceAttr("data.purefa_hostgroups.tfhostgroupstest", "hostgroups.0.hosts.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// Filter the host groups of the fake array.
+func TestDataSourcePureHostgroups_read(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.hgroups["esx"] = &fakeHgroup{Name: "esx", Hosts: []string{"esx1", "esx2"}}
+	fa.hgroups["linux"] = &fakeHgroup{Name: "linux", Hosts: []string{"linux1"}}
+	fa.hgroups["empty"] = &fakeHgroup{Name: "empty", Hosts: []string{}}
+
+	cases := []struct {
+		filter map[string]interface{}
+		names  string
+	}{
+		{map[string]interface{}{}, "[empty esx linux]"},
+		{map[string]interface{}{"name_regex": "^e"}, "[empty esx]"},
+		{map[string]interface{}{"host": "esx2"}, "[esx]"},
+		{map[string]interface{}{"host": "esx2", "name_glob": "linux"}, "[]"},
+	}
+	for _, c := range cases {
+		d := testFakeRead(t, dataSourcePureHostgroups(), c.filter, client)
+		if names := fmt.Sprint(d.Get("names")); names != c.names {
+			t.Errorf("host groups matching %v = %s, expected %s", c.filter, names, c.names)
+		}
+	}
+
+	d := testFakeRead(t, dataSourcePureHostgroups(), map[string]interface{}{"host": "esx1"}, client)
+	if fmt.Sprint(d.Get("hostgroups.0.hosts")) != "[esx1 esx2]" {
+		t.Fatalf("unexpected host group: %v", d.Get("hostgroups"))
+	}
+}
+
+func testAccCheckPureHostgroupsDataSourceConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_host" "tfhostgroupstest" {
+	name = "tfhostgroupstesthost%d"
+}
+
+resource "purefa_hostgroup" "tfhostgroupstest" {
+	name  = "tfhostgroupstest%d"
+	hosts = [purefa_host.tfhostgroupstest.name]
+}
+
+data "purefa_hostgroups" "tfhostgroupstest" {
+	host       = purefa_host.tfhostgroupstest.name
+	depends_on = [purefa_hostgroup.tfhostgroupstest]
+}`, rInt, rInt)
+}
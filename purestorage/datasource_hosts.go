@@ -0,0 +1,183 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePureHosts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePureHostsRead,
+
+		Schema: nameFilterSchema(map[string]*schema.Schema{
+			"hostgroup": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the hosts in this host group.",
+			},
+			"personality": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the hosts with this personality.",
+			},
+			"wwn_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the hosts with a WWN starting with this prefix. Colons are ignored.",
+			},
+			"iqn_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the hosts with an IQN starting with this prefix.",
+			},
+			"nqn_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the hosts with an NQN starting with this prefix.",
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"hosts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hostgroup": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"wwn": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"iqn": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"nqn": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"personality": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"preferred_array": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourcePureHostsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	match, err := nameFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hosts, err := client.Hosts.ListHosts(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The personality and preferred arrays of the hosts are only listed
+	// on their own.
+	personalities, err := client.Hosts.ListHosts(map[string]string{"personality": "true"})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	preferred, err := client.Hosts.ListHosts(map[string]string{"preferred_array": "true"})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for i := range hosts {
+		for _, p := range personalities {
+			if p.Name == hosts[i].Name {
+				hosts[i].Personality = p.Personality
+			}
+		}
+		for _, p := range preferred {
+			if p.Name == hosts[i].Name {
+				hosts[i].PreferredArray = p.PreferredArray
+			}
+		}
+	}
+
+	wwnPrefix := normalizeWwn(d.Get("wwn_prefix").(string))
+	iqnPrefix := strings.ToLower(d.Get("iqn_prefix").(string))
+	nqnPrefix := strings.ToLower(d.Get("nqn_prefix").(string))
+	var out []flasharray.Host
+	var names []string
+	for _, h := range hosts {
+		switch {
+		case !match(h.Name):
+		case d.Get("hostgroup").(string) != "" && h.Hgroup != d.Get("hostgroup").(string):
+		case d.Get("personality").(string) != "" && h.Personality != d.Get("personality").(string):
+		case wwnPrefix != "" && !hasPrefix(h.Wwn, wwnPrefix, normalizeWwn):
+		case iqnPrefix != "" && !hasPrefix(h.Iqn, iqnPrefix, strings.ToLower):
+		case nqnPrefix != "" && !hasPrefix(h.Nqn, nqnPrefix, strings.ToLower):
+		default:
+			out = append(out, h)
+			names = append(names, h.Name)
+		}
+	}
+
+	d.SetId(client.Target)
+	d.Set("names", names)
+	if err := d.Set("hosts", flattenHosts(out)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// normalizeWwn returns a WWN in the form the array reports it, in upper case
+// without colons.
+func normalizeWwn(wwn string) string {
+	return strings.ToUpper(strings.ReplaceAll(wwn, ":", ""))
+}
+
+// hasPrefix reports whether any of the normalized ports starts with prefix.
+func hasPrefix(ports []string, prefix string, normalize func(string) string) bool {
+	for _, p := range ports {
+		if strings.HasPrefix(normalize(p), prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// List the hosts with an IQN prefix
+func TestAccDataSourcePureHosts_read(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureHostsDataSourceConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.purefa_hosts.tfhoststest", "hosts.#", "1"),
+					resource.TestCheckResourceAttr("data.purefa_hosts.tfhoststest", "hosts.0.name", fmt.Sprintf("tfhoststest%d", rInt)),
+					resource.TestCheckResourceAttr("data.purefa_hosts.tfhoststest", "hosts.0.iqn.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// Filter the hosts of the fake array.
+func TestDataSourcePureHosts_read(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.hosts["esx1"] = &fakeHost{Name: "esx1", Wwn: []string{"21000024FF3A3A3A"}, Hgroup: "esx", Personality: "esxi"}
+	fa.hosts["esx2"] = &fakeHost{Name: "esx2", Wwn: []string{"21000024FF3A3A3B"}, Hgroup: "esx", Personality: "esxi"}
+	fa.hosts["linux1"] = &fakeHost{Name: "linux1", Iqn: []string{"iqn.1994-05.com.redhat:linux1"}, PreferredArray: []string{"fa2"}}
+	fa.hosts["nvme1"] = &fakeHost{Name: "nvme1", Nqn: []string{"nqn.2014-08.org.nvmexpress:uuid:1234"}}
+
+	cases := []struct {
+		filter map[string]interface{}
+		names  string
+	}{
+		{map[string]interface{}{}, "[esx1 esx2 linux1 nvme1]"},
+		{map[string]interface{}{"name_glob": "esx?"}, "[esx1 esx2]"},
+		{map[string]interface{}{"hostgroup": "esx"}, "[esx1 esx2]"},
+		{map[string]interface{}{"personality": "esxi", "name_regex": "2$"}, "[esx2]"},
+		{map[string]interface{}{"wwn_prefix": "21:00:00:24:ff:3a:3a:3b"}, "[esx2]"},
+		{map[string]interface{}{"wwn_prefix": "21:00"}, "[esx1 esx2]"},
+		{map[string]interface{}{"iqn_prefix": "iqn.1994-05.com.redhat"}, "[linux1]"},
+		{map[string]interface{}{"nqn_prefix": "nqn.2014-08"}, "[nvme1]"},
+		{map[string]interface{}{"iqn_prefix": "iqn.1991-05.com.microsoft"}, "[]"},
+	}
+	for _, c := range cases {
+		d := testFakeRead(t, dataSourcePureHosts(), c.filter, client)
+		if names := fmt.Sprint(d.Get("names")); names != c.names {
+			t.Errorf("hosts matching %v = %s, expected %s", c.filter, names, c.names)
+		}
+	}
+
+	d := testFakeRead(t, dataSourcePureHosts(), map[string]interface{}{"name_glob": "esx1"}, client)
+	if d.Get("hosts.0.hostgroup") != "esx" || d.Get("hosts.0.personality") != "esxi" || d.Get("hosts.0.wwn.0") != "21000024FF3A3A3A" {
+		t.Fatalf("unexpected host: %v", d.Get("hosts"))
+	}
+	d = testFakeRead(t, dataSourcePureHosts(), map[string]interface{}{"name_glob": "linux1"}, client)
+	if d.Get("hosts.0.preferred_array.0") != "fa2" || d.Get("hosts.0.personality") != "" {
+		t.Fatalf("unexpected host: %v", d.Get("hosts"))
+	}
+}
+
+func testAccCheckPureHostsDataSourceConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_host" "tfhoststest" {
+	name = "tfhoststest%d"
+	iqn  = ["iqn.2016-04.com.example:tfhoststest%d"]
+}
+
+data "purefa_hosts" "tfhoststest" {
+	iqn_prefix = "iqn.2016-04.com.example:tfhoststest%d"
+	depends_on = [purefa_host.tfhoststest]
+}`, rInt, rInt, rInt)
+}
@@ -0,0 +1,130 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePureProtectiongroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePureProtectiongroupsRead,
+
+		Schema: nameFilterSchema(map[string]*schema.Schema{
+			"source": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the protection groups of this source array or pod.",
+			},
+			"volume": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the protection groups this volume is a member of.",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the protection groups this host is a member of.",
+			},
+			"hostgroup": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the protection groups this host group is a member of.",
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"protectiongroups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"volumes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"hosts": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"hgroups": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"targets": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourcePureProtectiongroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	match, err := nameFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pgroups, err := client.Protectiongroups.ListProtectiongroups(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var out []flasharray.Protectiongroup
+	var names []string
+	for _, pg := range pgroups {
+		switch {
+		case !match(pg.Name):
+		case d.Get("source").(string) != "" && pg.Source != d.Get("source").(string):
+		case d.Get("volume").(string) != "" && !stringInSlice(d.Get("volume").(string), pg.Volumes):
+		case d.Get("host").(string) != "" && !stringInSlice(d.Get("host").(string), pg.Hosts):
+		case d.Get("hostgroup").(string) != "" && !stringInSlice(d.Get("hostgroup").(string), pg.Hgroups):
+		default:
+			out = append(out, pg)
+			names = append(names, pg.Name)
+		}
+	}
+
+	d.SetId(client.Target)
+	d.Set("names", names)
+	if err := d.Set("protectiongroups", flattenProtectiongroups(out)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// List the protection groups of a volume
+func TestAccDataSourcePureProtectiongroups_read(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureProtectiongroupsDataSourceConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.purefa_protectiongroups.tfprotectiongroupstest", "protectiongroups.#", "1"),
+					resource.TestCheckResourceAttr("data.purefa_protectiongroups.tfprotectiongroupstest", "protectiongroups.0.name", fmt.Sprintf("tfprotectiongroupstest%d", rInt)),
+					resource.TestCheckResourceAttr("data.purefa_protectiongroups.tfprotectiongroupstest", "protectiongroups.0.volumes.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// Filter the protection groups of the fake array.
+func TestDataSourcePureProtectiongroups_read(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.pgroups["db"] = &fakePgroup{Name: "db", Source: "fakearray", Volumes: []string{"db1", "db2"}, Targets: []map[string]interface{}{{"name": "fa2", "allowed": true}}}
+	fa.pgroups["esx"] = &fakePgroup{Name: "esx", Source: "fakearray", Hgroups: []string{"esx"}}
+	fa.pgroups["linux"] = &fakePgroup{Name: "linux", Source: "fakearray", Hosts: []string{"linux1"}}
+	fa.pgroups["pod1::pg"] = &fakePgroup{Name: "pod1::pg", Source: "pod1", Volumes: []string{"pod1::db1"}}
+	fa.pgroups["old"] = &fakePgroup{Name: "old", Source: "fakearray", Destroyed: true}
+
+	cases := []struct {
+		filter map[string]interface{}
+		names  string
+	}{
+		{map[string]interface{}{}, "[db esx linux pod1::pg]"},
+		{map[string]interface{}{"source": "pod1"}, "[pod1::pg]"},
+		{map[string]interface{}{"volume": "db2"}, "[db]"},
+		{map[string]interface{}{"host": "linux1"}, "[linux]"},
+		{map[string]interface{}{"hostgroup": "esx"}, "[esx]"},
+		{map[string]interface{}{"name_glob": "*::*"}, "[pod1::pg]"},
+	}
+	for _, c := range cases {
+		d := testFakeRead(t, dataSourcePureProtectiongroups(), c.filter, client)
+		if names := fmt.Sprint(d.Get("names")); names != c.names {
+			t.Errorf("protection groups matching %v = %s, expected %s", c.filter, names, c.names)
+		}
+	}
+
+	d := testFakeRead(t, dataSourcePureProtectiongroups(), map[string]interface{}{"name_glob": "db"}, client)
+	if d.Get("protectiongroups.0.source") != "fakearray" || fmt.Sprint(d.Get("protectiongroups.0.volumes")) != "[db1 db2]" || fmt.Sprint(d.Get("protectiongroups.0.targets")) != "[fa2]" {
+		t.Fatalf("unexpected protection group: %v", d.Get("protectiongroups"))
+	}
+}
+
+func testAccCheckPureProtectiongroupsDataSourceConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_volume" "tfprotectiongroupstest" {
+	name = "tfprotectiongroupstest-volume-%d"
+	size = 1024000000
+}
+
+resource "purefa_protectiongroup" "tfprotectiongroupstest" {
+	name    = "tfprotectiongroupstest%d"
+	volumes = [purefa_volume.tfprotectiongroupstest.name]
+}
+
+data "purefa_protectiongroups" "tfprotectiongroupstest" {
+	volume     = purefa_volume.tfprotectiongroupstest.name
+	depends_on = [purefa_protectiongroup.tfprotectiongroupstest]
+}`, rInt, rInt)
+}
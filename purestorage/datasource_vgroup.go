@@ -0,0 +1,74 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePureVolumegroup() *schema.Resource {
+	s := dataSourceSchemaFromResource(resourcePureVolumegroup().Schema)
+	delete(s, "eradicate_on_delete")
+	delete(s, "recover_if_destroyed")
+	s["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Name of the volume group",
+	}
+	s["volumes"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Full names of the volumes in the volume group.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourcePureVolumegroupRead,
+		Schema:      s,
+	}
+}
+
+func dataSourcePureVolumegroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	name := d.Get("name").(string)
+	d.SetId(name)
+	if diags := resourcePureVolumegroupRead(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	if d.Id() == "" {
+		return diag.Errorf("volume group '%s' not found", name)
+	}
+
+	volumes, err := client.Volumes.ListVolumes(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var names []string
+	for _, v := range volumes {
+		if strings.HasPrefix(v.Name, d.Id()+"/") {
+			names = append(names, v.Name)
+		}
+	}
+	d.Set("volumes", names)
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Read a volume group created outside of the data source
+func TestAccDataSourcePureVolumegroup_read(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureVolumegroupDataSourceConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.purefa_volumegroup.tfvolumegrouptest", "name", fmt.Sprintf("tfvolumegrouptest%d", rInt)),
+					resource.TestCheckResourceAttr("data.purefa_volumegroup.tfvolumegrouptest", "volumes.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// Read a volume group of the fake array.
+func TestDataSourcePureVolumegroup_read(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.vgroups["tfvolumegrouptest"] = &fakeVgroup{Name: "tfvolumegrouptest"}
+	fa.vgroups["tfvolumegroupdestroyed"] = &fakeVgroup{Name: "tfvolumegroupdestroyed", Destroyed: true}
+	fa.volumes["tfvolumegrouptest/vol1"] = &fakeVolume{Name: "tfvolumegrouptest/vol1"}
+	fa.volumes["tfvolumegrouptest/vol2"] = &fakeVolume{Name: "tfvolumegrouptest/vol2"}
+	fa.volumes["tfvolumegrouptest-vol3"] = &fakeVolume{Name: "tfvolumegrouptest-vol3"}
+
+	d := testFakeRead(t, dataSourcePureVolumegroup(), map[string]interface{}{"name": "tfvolumegrouptest"}, client)
+	if d.Id() != "tfvolumegrouptest" || fmt.Sprint(d.Get("volumes")) != "[tfvolumegrouptest/vol1 tfvolumegrouptest/vol2]" {
+		t.Fatalf("unexpected volume group: %s %v", d.Id(), d.Get("volumes"))
+	}
+
+	testFakeReadError(t, dataSourcePureVolumegroup(), map[string]interface{}{"name": "tfvolumegroupmissing"}, client)
+	testFakeReadError(t, dataSourcePureVolumegroup(), map[string]interface{}{"name": "tfvolumegroupdestroyed"}, client)
+}
+
+func testAccCheckPureVolumegroupDataSourceConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_volumegroup" "tfvolumegrouptest" {
+	name = "tfvolumegrouptest%d"
+}
+
+resource "purefa_volume" "tfvolumegrouptest" {
+	name         = "tfvolumegrouptest-volume-%d"
+	volume_group = purefa_volumegroup.tfvolumegrouptest.name
+	size         = 1024000000
+}
+
+data "purefa_volumegroup" "tfvolumegrouptest" {
+	name       = purefa_volumegroup.tfvolumegrouptest.name
+	depends_on = [purefa_volume.tfvolumegrouptest]
+}`, rInt, rInt)
+}
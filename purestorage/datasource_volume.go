@@ -0,0 +1,75 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+
+	"github.com/devans10/pugo/flasharray"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePureVolume() *schema.Resource {
+	s := dataSourceSchemaFromResource(resourcePureVolume().Schema)
+	delete(s, "allow_destroy")
+	delete(s, "eradicate_on_delete")
+	delete(s, "recover_if_destroyed")
+	s["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Name of the volume.",
+	}
+	s["volume_group"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{"pod"},
+		Description:   "Name of the volume group of the volume.",
+	}
+	s["pod"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{"volume_group"},
+		Description:   "Name of the pod of the volume.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourcePureVolumeRead,
+		Schema:      s,
+	}
+}
+
+func dataSourcePureVolumeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	name := volumeFullName(d.Get("pod"), d.Get("volume_group"), d.Get("name"))
+
+	d.SetId(name)
+	if diags := resourcePureVolumeRead(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	if d.Id() == "" {
+		return diag.Errorf("volume '%s' not found", name)
+	}
+
+	// The volume only reads the protection groups it manages.
+	pgroups, err := getVolumeProtectionGroups(m.(*flasharray.Client), name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("protection_group", pgroups)
+	return nil
+}
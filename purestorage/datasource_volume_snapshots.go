@@ -0,0 +1,83 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePureVolumeSnapshots() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePureVolumeSnapshotsRead,
+
+		Schema: map[string]*schema.Schema{
+			"volume": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Full name of the volume to list the snapshots of.",
+			},
+			"snapshots": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"suffix": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"serial": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePureVolumeSnapshotsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	volume := d.Get("volume").(string)
+	snapshots, err := listVolumeSnapshots(client, volume)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(volume)
+	if err := d.Set("snapshots", flattenVolumeSnapshots(snapshots)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Read a volume created outside of the data source
+func TestAccDataSourcePureVolume_read(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureVolumeDataSourceConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.purefa_volume.tfvolumetest", "serial", "purefa_volume.tfvolumetest", "serial"),
+					resource.TestCheckResourceAttr("data.purefa_volume.tfvolumetest", "size", "1024000000"),
+				),
+			},
+		},
+	})
+}
+
+// Read volumes of the fake array.
+func TestDataSourcePureVolume_read(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.volumes["tfvolumetest"] = &fakeVolume{Name: "tfvolumetest", Size: 1024000000, Serial: "A1", Source: "tfsource"}
+	fa.volumes["vg1/tfvolumetest"] = &fakeVolume{Name: "vg1/tfvolumetest", Size: 2048000000, Serial: "A2"}
+	fa.volumes["pod1::tfvolumetest"] = &fakeVolume{Name: "pod1::tfvolumetest", Size: 4096000000, Serial: "A3"}
+	fa.volumes["tfvolumedestroyed"] = &fakeVolume{Name: "tfvolumedestroyed", Destroyed: true}
+
+	d := testFakeRead(t, dataSourcePureVolume(), map[string]interface{}{"name": "tfvolumetest"}, client)
+	if d.Id() != "tfvolumetest" || d.Get("serial") != "A1" || d.Get("size_bytes") != 1024000000 || d.Get("size_human") != "976.56M" || d.Get("source") != "tfsource" {
+		t.Fatalf("unexpected volume: %s %v %v %v %v", d.Id(), d.Get("serial"), d.Get("size_bytes"), d.Get("size_human"), d.Get("source"))
+	}
+	d = testFakeRead(t, dataSourcePureVolume(), map[string]interface{}{"name": "tfvolumetest", "volume_group": "vg1"}, client)
+	if d.Get("full_name") != "vg1/tfvolumetest" || d.Get("serial") != "A2" {
+		t.Fatalf("unexpected volume: %v %v", d.Get("full_name"), d.Get("serial"))
+	}
+	d = testFakeRead(t, dataSourcePureVolume(), map[string]interface{}{"name": "tfvolumetest", "pod": "pod1"}, client)
+	if d.Get("full_name") != "pod1::tfvolumetest" || d.Get("serial") != "A3" {
+		t.Fatalf("unexpected volume: %v %v", d.Get("full_name"), d.Get("serial"))
+	}
+
+	for _, name := range []string{"tfvolumemissing", "tfvolumedestroyed"} {
+		diags := testFakeReadError(t, dataSourcePureVolume(), map[string]interface{}{"name": name}, client)
+		if !strings.Contains(diags[0].Summary, "not found") {
+			t.Errorf("unexpected error for %s: %v", name, diags)
+		}
+	}
+}
+
+func testAccCheckPureVolumeDataSourceConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_volume" "tfvolumetest" {
+	name = "tfvolumetest-%d"
+	size = 1024000000
+}
+
+data "purefa_volume" "tfvolumetest" {
+	name = purefa_volume.tfvolumetest.name
+}`, rInt)
+}
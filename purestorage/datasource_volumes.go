@@ -0,0 +1,151 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePureVolumes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePureVolumesRead,
+
+		Schema: nameFilterSchema(map[string]*schema.Schema{
+			"volume_group": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the volumes in this volume group.",
+			},
+			"pod": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the volumes in this pod.",
+			},
+			"min_size": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSize,
+				Description:  "Only list the volumes with at least this provisioned size. Number in bytes, with an optional K, M, G, T or P suffix in multiples of 1024, e.g. 10G.",
+			},
+			"max_size": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSize,
+				Description:  "Only list the volumes with at most this provisioned size. Number in bytes, with an optional K, M, G, T or P suffix in multiples of 1024, e.g. 10G.",
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"volumes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"full_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"volume_group": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pod": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"serial": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourcePureVolumesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	match, err := nameFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	volumes, err := client.Volumes.ListVolumes(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var minSize, maxSize int
+	if v, ok := d.GetOk("min_size"); ok {
+		if minSize, err = parseSize(v.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := d.GetOk("max_size"); ok {
+		if maxSize, err = parseSize(v.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	var out []flasharray.Volume
+	var names []string
+	for _, v := range volumes {
+		pod, volumeGroup, _, err := splitVolumeName(v.Name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		switch {
+		case !match(v.Name):
+		case d.Get("volume_group").(string) != "" && volumeGroup != d.Get("volume_group").(string):
+		case d.Get("pod").(string) != "" && pod != d.Get("pod").(string):
+		case v.Size < minSize:
+		case maxSize > 0 && v.Size > maxSize:
+		default:
+			out = append(out, v)
+			names = append(names, v.Name)
+		}
+	}
+
+	d.SetId(client.Target)
+	d.Set("names", names)
+	if err := d.Set("volumes", flattenVolumes(out)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
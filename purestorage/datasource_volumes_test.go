@@ -0,0 +1,98 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// List the volumes matching a name pattern
+func TestAccDataSourcePureVolumes_read(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureVolumesDataSourceConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.purefa_volumes.tfvolumestest", "volumes.#", "1"),
+					resource.TestCheckResourceAttr("data.purefa_volumes.tfvolumestest", "volumes.0.name", fmt.Sprintf("tfvolumestest-%d", rInt)),
+					resource.TestCheckResourceAttr("data.purefa_volumes.tfvolumestest", "volumes.0.size", "1024000000"),
+					resource.TestCheckResourceAttrSet("data.purefa_volumes.tfvolumestest", "volumes.0.serial"),
+				),
+			},
+		},
+	})
+}
+
+// Filter the volumes of the fake array.
+func TestDataSourcePureVolumes_read(t *testing.T) {
+	fa, client := testFakeArray(t)
+	gib := 1024 * 1024 * 1024
+	fa.volumes["prod-db"] = &fakeVolume{Name: "prod-db", Size: 100 * gib, Serial: "A1"}
+	fa.volumes["prod-web"] = &fakeVolume{Name: "prod-web", Size: gib, Serial: "A2"}
+	fa.volumes["vg1/prod-log"] = &fakeVolume{Name: "vg1/prod-log", Size: 10 * gib, Serial: "A3"}
+	fa.volumes["pod1::dev-db"] = &fakeVolume{Name: "pod1::dev-db", Size: 100 * gib, Serial: "A4"}
+	fa.volumes["old"] = &fakeVolume{Name: "old", Size: gib, Destroyed: true}
+
+	cases := []struct {
+		filter map[string]interface{}
+		names  string
+	}{
+		{map[string]interface{}{}, "[pod1::dev-db prod-db prod-web vg1/prod-log]"},
+		{map[string]interface{}{"name_glob": "prod-*"}, "[prod-db prod-web]"},
+		{map[string]interface{}{"name_regex": "-db$"}, "[pod1::dev-db prod-db]"},
+		{map[string]interface{}{"volume_group": "vg1"}, "[vg1/prod-log]"},
+		{map[string]interface{}{"pod": "pod1"}, "[pod1::dev-db]"},
+		{map[string]interface{}{"min_size": "10G"}, "[pod1::dev-db prod-db vg1/prod-log]"},
+		{map[string]interface{}{"min_size": "10G", "max_size": fmt.Sprint(10 * gib)}, "[vg1/prod-log]"},
+	}
+	for _, c := range cases {
+		d := testFakeRead(t, dataSourcePureVolumes(), c.filter, client)
+		if names := fmt.Sprint(d.Get("names")); names != c.names {
+			t.Errorf("volumes matching %v = %s, expected %s", c.filter, names, c.names)
+		}
+	}
+
+	d := testFakeRead(t, dataSourcePureVolumes(), map[string]interface{}{"volume_group": "vg1"}, client)
+	if d.Get("volumes.0.name") != "prod-log" || d.Get("volumes.0.full_name") != "vg1/prod-log" || d.Get("volumes.0.volume_group") != "vg1" || d.Get("volumes.0.size") != 10*gib || d.Get("volumes.0.serial") != "A3" {
+		t.Fatalf("unexpected volume: %v", d.Get("volumes"))
+	}
+	d = testFakeRead(t, dataSourcePureVolumes(), map[string]interface{}{"pod": "pod1"}, client)
+	if d.Get("volumes.0.name") != "dev-db" || d.Get("volumes.0.pod") != "pod1" {
+		t.Fatalf("unexpected volume: %v", d.Get("volumes"))
+	}
+}
+
+func testAccCheckPureVolumesDataSourceConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_volume" "tfvolumestest" {
+	name = "tfvolumestest-%d"
+	size = 1024000000
+}
+
+data "purefa_volumes" "tfvolumestest" {
+	name_glob  = "tfvolumestest-%d"
+	depends_on = [purefa_volume.tfvolumestest]
+}`, rInt, rInt)
+}
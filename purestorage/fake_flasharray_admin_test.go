@@ -0,0 +1,206 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+var fakeAdminRoles = []string{"readonly", "ops_admin", "storage_admin", "array_admin"}
+
+type fakeAdmin struct {
+	Name      string
+	Role      string
+	Password  string
+	PublicKey string
+
+	Token        string
+	TokenCreated string
+	TokenExpires string
+}
+
+func (a *fakeAdmin) view(params map[string]string) map[string]interface{} {
+	switch {
+	case params["publickey"] == "true":
+		return map[string]interface{}{"name": a.Name, "type": "local", "publickey": fakeOptional(a.PublicKey)}
+	case params["api_token"] == "true":
+		return a.tokenView(params)
+	}
+	return map[string]interface{}{"name": a.Name, "type": "local", "role": a.Role}
+}
+
+// tokenView masks the API token unless it is exposed, like the array does.
+func (a *fakeAdmin) tokenView(params map[string]string) map[string]interface{} {
+	token := a.Token
+	if token != "" && params["expose"] != "true" {
+		token = "****"
+	}
+	return map[string]interface{}{
+		"name":      a.Name,
+		"type":      "local",
+		"api_token": fakeOptional(token),
+		"created":   fakeOptional(a.TokenCreated),
+		"expires":   fakeOptional(a.TokenExpires),
+	}
+}
+
+// fakeAdminByToken returns the admin an API token belongs to.
+func (fa *fakeFlashArray) fakeAdminByToken(token string) *fakeAdmin {
+	for _, a := range fa.admins {
+		if a.Token != "" && a.Token == token {
+			return a
+		}
+	}
+	return nil
+}
+
+func (fa *fakeFlashArray) handleAdmin(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	params := fakeParams(r)
+	if rest == "" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		var names []string
+		for name := range fa.admins {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out := []map[string]interface{}{}
+		for _, name := range names {
+			if a := fa.admins[name]; params["api_token"] != "true" || a.Token != "" {
+				out = append(out, a.view(params))
+			}
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	parts := strings.SplitN(rest, "/", 2)
+	name := parts[0]
+	a, exists := fa.admins[name]
+	if r.Method == http.MethodPost && len(parts) == 1 {
+		if exists {
+			fakeRespondError(w, http.StatusBadRequest, name, "Admin already exists.")
+			return
+		}
+		a = &fakeAdmin{Name: name}
+		if fakeString(body, "password") == "" {
+			fakeRespondError(w, http.StatusBadRequest, name, "Password is required.")
+			return
+		}
+		if !fa.setAdmin(w, a, body) {
+			return
+		}
+		fa.admins[name] = a
+		fakeRespond(w, http.StatusOK, a.view(nil))
+		return
+	}
+	if !exists {
+		fakeRespondError(w, http.StatusBadRequest, name, "Admin does not exist.")
+		return
+	}
+	if len(parts) == 2 {
+		if parts[1] != "apitoken" {
+			fakeRespondError(w, http.StatusNotFound, "", "Not found.")
+			return
+		}
+		fa.handleAdminAPIToken(w, r, a, body)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if fakeString(body, "action") == "refresh" {
+			break
+		}
+		if !fa.setAdmin(w, a, body) {
+			return
+		}
+	case http.MethodDelete:
+		if name == fa.Username {
+			fakeRespondError(w, http.StatusBadRequest, name, "Cannot delete the built-in admin.")
+			return
+		}
+		delete(fa.admins, name)
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+		return
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, a.view(params))
+}
+
+// setAdmin validates and applies the role, password and public key in body.
+func (fa *fakeFlashArray) setAdmin(w http.ResponseWriter, a *fakeAdmin, body map[string]interface{}) bool {
+	role := a.Role
+	if _, ok := body["role"]; ok {
+		role = fakeString(body, "role")
+	}
+	if !stringInSlice(role, fakeAdminRoles) {
+		fakeRespondError(w, http.StatusBadRequest, role, "Invalid role.")
+		return false
+	}
+	if _, ok := body["old_password"]; ok && fakeString(body, "old_password") != a.Password {
+		fakeRespondError(w, http.StatusBadRequest, a.Name, "Old password is incorrect.")
+		return false
+	}
+	if key := fakeString(body, "publickey"); key != "" && !strings.HasPrefix(key, "ssh-") {
+		fakeRespondError(w, http.StatusBadRequest, a.Name, "Invalid public key.")
+		return false
+	}
+
+	a.Role = role
+	if password := fakeString(body, "password"); password != "" {
+		a.Password = password
+	}
+	if _, ok := body["publickey"]; ok {
+		a.PublicKey = fakeString(body, "publickey")
+	}
+	return true
+}
+
+func (fa *fakeFlashArray) handleAdminAPIToken(w http.ResponseWriter, r *http.Request, a *fakeAdmin, body map[string]interface{}) {
+	params := fakeParams(r)
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if a.Token != "" {
+			fakeRespondError(w, http.StatusBadRequest, a.Name, "Admin already has an API token.")
+			return
+		}
+		now := time.Now().UTC()
+		a.Token = fakeRandomHex(4) + "-" + fakeRandomHex(2) + "-" + fakeRandomHex(2) + "-" + fakeRandomHex(2) + "-" + fakeRandomHex(6)
+		a.TokenCreated = now.Format("2006-01-02T15:04:05Z")
+		a.TokenExpires = ""
+		if timeout, ok := fakeInt(body, "timeout"); ok && timeout > 0 {
+			a.TokenExpires = now.Add(time.Duration(timeout) * time.Millisecond).Format("2006-01-02T15:04:05Z")
+		}
+		params = map[string]string{"expose": "true"}
+	case http.MethodDelete:
+		a.Token, a.TokenCreated, a.TokenExpires = "", "", ""
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, a.tokenView(params))
+}
@@ -0,0 +1,245 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+type fakeCert struct {
+	Name         string
+	SelfSigned   bool
+	Certificate  string
+	Intermediate string
+	PrivateKey   string
+	CommonName   string
+	Country      string
+	State        string
+	Locality     string
+	Org          string
+	OrgUnit      string
+	Email        string
+	KeySize      int
+	ValidFrom    time.Time
+	ValidTo      time.Time
+}
+
+// fakeCertPEM returns a PEM block with random content. The fake array does
+// not parse certificates.
+func fakeCertPEM(kind string) string {
+	return fmt.Sprintf("-----BEGIN %s-----\n%s\n-----END %s-----", kind, fakeRandomHex(32), kind)
+}
+
+// view returns the attributes of the certificate. The array returns the
+// validity dates in milliseconds since the epoch.
+func (c *fakeCert) view(params map[string]string) map[string]interface{} {
+	if params["certificate"] == "true" {
+		return map[string]interface{}{"name": c.Name, "certificate": c.Certificate}
+	}
+	if params["intermediate_certificate"] == "true" {
+		return map[string]interface{}{"name": c.Name, "intermediate_certificate": fakeOptional(c.Intermediate)}
+	}
+	status, issuedBy := "imported", "CA"
+	if c.SelfSigned {
+		status, issuedBy = "self-signed", c.CommonName
+	}
+	return map[string]interface{}{
+		"name":                c.Name,
+		"self_signed":         c.SelfSigned,
+		"status":              status,
+		"common_name":         fakeOptional(c.CommonName),
+		"country":             fakeOptional(c.Country),
+		"state":               fakeOptional(c.State),
+		"locality":            fakeOptional(c.Locality),
+		"organization":        fakeOptional(c.Org),
+		"organizational_unit": fakeOptional(c.OrgUnit),
+		"email":               fakeOptional(c.Email),
+		"key_size":            c.KeySize,
+		"issued_to":           fakeOptional(c.CommonName),
+		"issued_by":           fakeOptional(issuedBy),
+		"valid_from":          c.ValidFrom.UnixNano() / int64(time.Millisecond),
+		"valid_to":            c.ValidTo.UnixNano() / int64(time.Millisecond),
+	}
+}
+
+func (fa *fakeFlashArray) handleCert(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	params := fakeParams(r)
+	if rest == "" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		var names []string
+		for name := range fa.certs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out := []map[string]interface{}{}
+		for _, name := range names {
+			out = append(out, fa.certs[name].view(params))
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	if name := strings.TrimPrefix(rest, "certificate_signing_request/"); name != rest {
+		fa.handleCSR(w, r, name, params)
+		return
+	}
+
+	c, exists := fa.certs[rest]
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost, http.MethodPut:
+		if r.Method == http.MethodPost && exists {
+			fakeRespondError(w, http.StatusBadRequest, rest, "Certificate already exists.")
+			return
+		}
+		if r.Method == http.MethodPut && !exists {
+			break
+		}
+		if c == nil {
+			c = &fakeCert{Name: rest}
+		}
+		if !fa.setCert(w, c, body, exists) {
+			return
+		}
+		fa.certs[rest] = c
+		exists = true
+	case http.MethodDelete:
+		if rest == "management" {
+			fakeRespondError(w, http.StatusBadRequest, rest, "Cannot delete the management certificate.")
+			return
+		}
+		if exists {
+			delete(fa.certs, rest)
+			fakeRespond(w, http.StatusOK, map[string]interface{}{"name": rest})
+			return
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	if !exists {
+		fakeRespondError(w, http.StatusBadRequest, rest, "Certificate does not exist.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, c.view(params))
+}
+
+// setCert generates a self-signed certificate, or imports the certificate in
+// body. A certificate can be imported without a private key when the
+// certificate already has one, e.g. for a signed certificate signing request.
+func (fa *fakeFlashArray) setCert(w http.ResponseWriter, c *fakeCert, body map[string]interface{}, hasKey bool) bool {
+	now := time.Now().UTC().Truncate(time.Second)
+	if fakeBool(body, "self_signed") {
+		n := *c
+		n.SelfSigned = true
+		n.Certificate = fakeCertPEM("CERTIFICATE")
+		n.Intermediate = ""
+		for key, field := range map[string]*string{
+			"common_name":         &n.CommonName,
+			"country":             &n.Country,
+			"state":               &n.State,
+			"locality":            &n.Locality,
+			"organization":        &n.Org,
+			"organizational_unit": &n.OrgUnit,
+			"email":               &n.Email,
+		} {
+			if _, ok := body[key]; ok {
+				*field = fakeString(body, key)
+			}
+		}
+		n.KeySize = 2048
+		if size, ok := fakeInt(body, "key_size"); ok {
+			n.KeySize = size
+		}
+		days := 3650
+		if d, ok := fakeInt(body, "days"); ok {
+			days = d
+		}
+		n.ValidFrom, n.ValidTo = now, now.AddDate(0, 0, days)
+		if len(n.Country) > 2 {
+			fakeRespondError(w, http.StatusBadRequest, "country", "Country must be a two letter code.")
+			return false
+		}
+		*c = n
+		return true
+	}
+
+	cert := fakeString(body, "certificate")
+	if !strings.HasPrefix(cert, "-----BEGIN CERTIFICATE-----") {
+		fakeRespondError(w, http.StatusBadRequest, "certificate", "Invalid certificate.")
+		return false
+	}
+	key := fakeString(body, "private_key")
+	if key == "" && !hasKey {
+		fakeRespondError(w, http.StatusBadRequest, "private_key", "Private key is required.")
+		return false
+	}
+	if key != "" && !strings.Contains(key, "PRIVATE KEY-----") {
+		fakeRespondError(w, http.StatusBadRequest, "private_key", "Invalid private key.")
+		return false
+	}
+	if key != "" {
+		c.PrivateKey = key
+	}
+	c.SelfSigned = false
+	c.Certificate = cert
+	c.Intermediate = fakeString(body, "intermediate_certificate")
+	c.ValidFrom, c.ValidTo = now, now.AddDate(1, 0, 0)
+	return true
+}
+
+func (fa *fakeFlashArray) handleCSR(w http.ResponseWriter, r *http.Request, name string, params map[string]string) {
+	if r.Method != http.MethodGet {
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	c, ok := fa.certs[name]
+	if !ok {
+		fakeRespondError(w, http.StatusBadRequest, name, "Certificate does not exist.")
+		return
+	}
+	subject := []string{c.CommonName}
+	for _, key := range []string{"common_name", "country", "state", "locality", "organization", "organizational_unit", "email"} {
+		subject = append(subject, params[key])
+	}
+	// The request is derived from the subject, so the same subject always
+	// returns the same request.
+	csr := fmt.Sprintf("-----BEGIN CERTIFICATE REQUEST-----\n%x\n-----END CERTIFICATE REQUEST-----", strings.Join(subject, "/"))
+	fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name, "certificate_signing_request": csr})
+}
+
+func fakeDefaultCerts() map[string]*fakeCert {
+	now := time.Now().UTC().Truncate(time.Second)
+	return map[string]*fakeCert{
+		"management": {
+			Name:        "management",
+			SelfSigned:  true,
+			Certificate: fakeCertPEM("CERTIFICATE"),
+			CommonName:  "fakearray",
+			KeySize:     2048,
+			ValidFrom:   now,
+			ValidTo:     now.AddDate(10, 0, 0),
+		},
+	}
+}
@@ -0,0 +1,198 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var fakeDirsrvRoles = []string{"array_admin", "ops_admin", "readonly", "storage_admin"}
+
+type fakeDirsrv struct {
+	URI          []string
+	BaseDn       string
+	BindUser     string
+	BindPassword string
+	Certificate  string
+	CheckPeer    bool
+	Enabled      bool
+
+	// Groups and GroupBases are the LDAP groups mapped to the array roles.
+	Groups     map[string]string
+	GroupBases map[string]string
+}
+
+func newFakeDirsrv() *fakeDirsrv {
+	return &fakeDirsrv{Groups: make(map[string]string), GroupBases: make(map[string]string)}
+}
+
+func (s *fakeDirsrv) view() map[string]interface{} {
+	password := s.BindPassword
+	if password != "" {
+		password = "****"
+	}
+	return map[string]interface{}{
+		"uri":           s.URI,
+		"base_dn":       fakeOptional(s.BaseDn),
+		"bind_user":     fakeOptional(s.BindUser),
+		"bind_password": fakeOptional(password),
+		"check_peer":    s.CheckPeer,
+		"enabled":       s.Enabled,
+	}
+}
+
+func (s *fakeDirsrv) roleView(role string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       role,
+		"group":      fakeOptional(s.Groups[role]),
+		"group_base": fakeOptional(s.GroupBases[role]),
+	}
+}
+
+// test returns the output of a directory service test. URIs with a host
+// named unreachable fail the test.
+func (s *fakeDirsrv) test() string {
+	var lines []string
+	for _, uri := range s.URI {
+		result := "PASSED"
+		if strings.Contains(uri, "unreachable") {
+			result = "FAILED"
+		}
+		lines = append(lines, fmt.Sprintf("Testing from ct0:\n    Connecting to %s... %s", uri, result))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (fa *fakeFlashArray) handleDirsrv(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	s := fa.dirsrv
+	if rest != "" {
+		fa.handleDirsrvRole(w, r, rest, body)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if fakeParams(r)["certificate"] == "true" {
+			fakeRespond(w, http.StatusOK, map[string]interface{}{"certificate": fakeOptional(s.Certificate)})
+			return
+		}
+	case http.MethodPut:
+		if fakeString(body, "action") == "test" {
+			if len(s.URI) == 0 {
+				fakeRespondError(w, http.StatusBadRequest, "uri", "Directory service is not configured.")
+				return
+			}
+			fakeRespond(w, http.StatusOK, map[string]interface{}{"output": s.test()})
+			return
+		}
+		if !fa.setDirsrv(w, body) {
+			return
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, s.view())
+}
+
+// setDirsrv validates and applies the directory service attributes in body.
+func (fa *fakeFlashArray) setDirsrv(w http.ResponseWriter, body map[string]interface{}) bool {
+	s := *fa.dirsrv
+	if _, ok := body["uri"]; ok {
+		s.URI = fakeStrings(body, "uri")
+	}
+	for _, uri := range s.URI {
+		if !strings.HasPrefix(uri, "ldap://") && !strings.HasPrefix(uri, "ldaps://") {
+			fakeRespondError(w, http.StatusBadRequest, uri, "Invalid URI.")
+			return false
+		}
+	}
+	if _, ok := body["base_dn"]; ok {
+		s.BaseDn = fakeString(body, "base_dn")
+	}
+	if _, ok := body["bind_user"]; ok {
+		s.BindUser = fakeString(body, "bind_user")
+	}
+	if _, ok := body["bind_password"]; ok {
+		s.BindPassword = fakeString(body, "bind_password")
+	}
+	if _, ok := body["certificate"]; ok {
+		s.Certificate = fakeString(body, "certificate")
+		if s.Certificate != "" && !strings.HasPrefix(s.Certificate, "-----BEGIN CERTIFICATE-----") {
+			fakeRespondError(w, http.StatusBadRequest, "certificate", "Invalid certificate.")
+			return false
+		}
+	}
+	if _, ok := body["check_peer"]; ok {
+		s.CheckPeer = fakeBool(body, "check_peer")
+	}
+	if _, ok := body["enabled"]; ok {
+		s.Enabled = fakeBool(body, "enabled")
+	}
+	if s.CheckPeer && s.Certificate == "" {
+		fakeRespondError(w, http.StatusBadRequest, "check_peer", "A CA certificate is required to check the peer.")
+		return false
+	}
+	if s.Enabled && (len(s.URI) == 0 || s.BaseDn == "") {
+		fakeRespondError(w, http.StatusBadRequest, "enabled", "URI and base DN are required to enable the directory service.")
+		return false
+	}
+	*fa.dirsrv = s
+	return true
+}
+
+func (fa *fakeFlashArray) handleDirsrvRole(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	s := fa.dirsrv
+	if rest == "role" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		out := []map[string]interface{}{}
+		for _, role := range fakeDirsrvRoles {
+			out = append(out, s.roleView(role))
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	role := strings.TrimPrefix(rest, "role/")
+	if role == rest {
+		fakeRespondError(w, http.StatusNotFound, "", "Not found.")
+		return
+	}
+	if !stringInSlice(role, fakeDirsrvRoles) {
+		fakeRespondError(w, http.StatusBadRequest, role, "Invalid role.")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if _, ok := body["group"]; ok {
+			s.Groups[role] = fakeString(body, "group")
+		}
+		if _, ok := body["group_base"]; ok {
+			s.GroupBases[role] = fakeString(body, "group_base")
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, s.roleView(role))
+}
@@ -0,0 +1,590 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// fakeMaxLun is the highest LUN the array hands out. Shared connections are
+// allocated downwards from it, private connections upwards from 1.
+const fakeMaxLun = 254
+
+type fakeHost struct {
+	Name           string
+	Wwn            []string
+	Iqn            []string
+	Nqn            []string
+	PreferredArray []string
+	Personality    string
+	HostUser       string
+	HostPassword   string
+	TargetUser     string
+	TargetPassword string
+	Hgroup         string
+
+	// Volumes maps private connections to their LUN.
+	Volumes map[string]int
+}
+
+type fakeHgroup struct {
+	Name  string
+	Hosts []string
+
+	// Volumes maps shared connections to their LUN.
+	Volumes map[string]int
+}
+
+func fakeMasked(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return "****"
+}
+
+func fakeOptional(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func (h *fakeHost) view(params map[string]string) map[string]interface{} {
+	switch {
+	case params["personality"] == "true":
+		return map[string]interface{}{"name": h.Name, "personality": fakeOptional(h.Personality)}
+	case params["preferred_array"] == "true":
+		return map[string]interface{}{"name": h.Name, "preferred_array": h.PreferredArray}
+	case params["chap"] == "true":
+		return map[string]interface{}{
+			"name":            h.Name,
+			"host_user":       fakeOptional(h.HostUser),
+			"host_password":   fakeMasked(h.HostPassword),
+			"target_user":     fakeOptional(h.TargetUser),
+			"target_password": fakeMasked(h.TargetPassword),
+		}
+	}
+	return map[string]interface{}{
+		"name":   h.Name,
+		"wwn":    h.Wwn,
+		"iqn":    h.Iqn,
+		"nqn":    h.Nqn,
+		"hgroup": fakeOptional(h.Hgroup),
+	}
+}
+
+func (fa *fakeFlashArray) sortedHosts() []*fakeHost {
+	var out []*fakeHost
+	for _, h := range fa.hosts {
+		out = append(out, h)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (fa *fakeFlashArray) sortedHgroups() []*fakeHgroup {
+	var out []*fakeHgroup
+	for _, hg := range fa.hgroups {
+		out = append(out, hg)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// fakeWwns normalizes WWNs the way the array stores them.
+func fakeWwns(l []string) []string {
+	out := []string{}
+	for _, wwn := range l {
+		out = append(out, strings.ToUpper(strings.Replace(wwn, ":", "", -1)))
+	}
+	return out
+}
+
+// checkPorts verifies that none of the ports is used by another host.
+func (fa *fakeFlashArray) checkPorts(w http.ResponseWriter, host string, wwns []string, iqns []string, nqns []string) bool {
+	for _, h := range fa.hosts {
+		if h.Name == host {
+			continue
+		}
+		for _, p := range wwns {
+			if stringInSlice(p, h.Wwn) {
+				fakeRespondError(w, http.StatusBadRequest, p, "The specified WWN is already in use.")
+				return false
+			}
+		}
+		for _, p := range iqns {
+			if stringInSlice(p, h.Iqn) {
+				fakeRespondError(w, http.StatusBadRequest, p, "The specified IQN is already in use.")
+				return false
+			}
+		}
+		for _, p := range nqns {
+			if stringInSlice(p, h.Nqn) {
+				fakeRespondError(w, http.StatusBadRequest, p, "The specified NQN is already in use.")
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hostLuns returns the LUNs in use by a host, private and shared.
+func (fa *fakeFlashArray) hostLuns(h *fakeHost) map[int]bool {
+	luns := make(map[int]bool)
+	for _, lun := range h.Volumes {
+		luns[lun] = true
+	}
+	if hg, ok := fa.hgroups[h.Hgroup]; ok {
+		for _, lun := range hg.Volumes {
+			luns[lun] = true
+		}
+	}
+	return luns
+}
+
+// connectableVolume verifies that a volume can be connected.
+func (fa *fakeFlashArray) connectableVolume(w http.ResponseWriter, name string) bool {
+	v, ok := fa.volumes[name]
+	if !ok || v.Destroyed {
+		fakeRespondError(w, http.StatusBadRequest, name, "Volume does not exist.")
+		return false
+	}
+	return true
+}
+
+func (fa *fakeFlashArray) handleHost(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	params := fakeParams(r)
+	if rest == "" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		out := []map[string]interface{}{}
+		for _, h := range fa.sortedHosts() {
+			out = append(out, h.view(params))
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	parts := strings.SplitN(rest, "/", 3)
+	name := parts[0]
+	h, exists := fa.hosts[name]
+	if r.Method == http.MethodPost && len(parts) == 1 {
+		if exists {
+			fakeRespondError(w, http.StatusBadRequest, name, "Host already exists.")
+			return
+		}
+		h = &fakeHost{
+			Name:           name,
+			Wwn:            fakeWwns(fakeStrings(body, "wwnlist")),
+			Iqn:            fakeStrings(body, "iqnlist"),
+			Nqn:            fakeStrings(body, "nqnlist"),
+			PreferredArray: fakeStrings(body, "preferred_array"),
+			Volumes:        make(map[string]int),
+		}
+		if !fa.checkPorts(w, name, h.Wwn, h.Iqn, h.Nqn) {
+			return
+		}
+		fa.hosts[name] = h
+		fakeRespond(w, http.StatusOK, h.view(nil))
+		return
+	}
+	if !exists {
+		fakeRespondError(w, http.StatusBadRequest, name, "Host does not exist.")
+		return
+	}
+
+	if len(parts) > 1 {
+		switch parts[1] {
+		case "volume":
+			fa.handleHostVolume(w, r, h, parts, params, body)
+		case "pgroup":
+			if len(parts) != 3 {
+				fakeRespondError(w, http.StatusNotFound, "", "Not found.")
+				return
+			}
+			fa.handleMemberPgroup(w, r, "host", name, parts[2])
+		default:
+			fakeRespondError(w, http.StatusNotFound, "", "Not found.")
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if !fa.setHost(w, h, body) {
+			return
+		}
+	case http.MethodDelete:
+		if h.Hgroup != "" {
+			fakeRespondError(w, http.StatusBadRequest, name, "Host is a member of a host group.")
+			return
+		}
+		if len(h.Volumes) > 0 {
+			fakeRespondError(w, http.StatusBadRequest, name, "Host has connected volumes.")
+			return
+		}
+		delete(fa.hosts, name)
+		for _, pg := range fa.pgroups {
+			pg.Hosts = fakeRemoveString(pg.Hosts, name)
+		}
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+		return
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, h.view(params))
+}
+
+func (fa *fakeFlashArray) setHost(w http.ResponseWriter, h *fakeHost, body map[string]interface{}) bool {
+	wwns, iqns, nqns := h.Wwn, h.Iqn, h.Nqn
+	if _, ok := body["wwnlist"]; ok {
+		wwns = fakeWwns(fakeStrings(body, "wwnlist"))
+	}
+	if _, ok := body["iqnlist"]; ok {
+		iqns = fakeStrings(body, "iqnlist")
+	}
+	if _, ok := body["nqnlist"]; ok {
+		nqns = fakeStrings(body, "nqnlist")
+	}
+	for _, p := range fakeWwns(fakeStrings(body, "addwwnlist")) {
+		wwns = append(wwns, p)
+	}
+	for _, p := range fakeStrings(body, "addiqnlist") {
+		iqns = append(iqns, p)
+	}
+	for _, p := range fakeStrings(body, "addnqnlist") {
+		nqns = append(nqns, p)
+	}
+	for _, p := range fakeWwns(fakeStrings(body, "remwwnlist")) {
+		wwns = fakeRemoveString(wwns, p)
+	}
+	for _, p := range fakeStrings(body, "remiqnlist") {
+		iqns = fakeRemoveString(iqns, p)
+	}
+	for _, p := range fakeStrings(body, "remnqnlist") {
+		nqns = fakeRemoveString(nqns, p)
+	}
+	if !fa.checkPorts(w, h.Name, wwns, iqns, nqns) {
+		return false
+	}
+
+	if n, ok := body["name"]; ok && n.(string) != h.Name {
+		newName := n.(string)
+		if _, ok := fa.hosts[newName]; ok {
+			fakeRespondError(w, http.StatusBadRequest, newName, "Host already exists.")
+			return false
+		}
+		delete(fa.hosts, h.Name)
+		if hg, ok := fa.hgroups[h.Hgroup]; ok {
+			hg.Hosts = fakeReplaceString(hg.Hosts, h.Name, newName)
+		}
+		for _, pg := range fa.pgroups {
+			pg.Hosts = fakeReplaceString(pg.Hosts, h.Name, newName)
+		}
+		h.Name = newName
+		fa.hosts[newName] = h
+	}
+
+	h.Wwn, h.Iqn, h.Nqn = wwns, iqns, nqns
+	if _, ok := body["preferred_array"]; ok {
+		h.PreferredArray = fakeStrings(body, "preferred_array")
+	}
+	if _, ok := body["personality"]; ok {
+		h.Personality = fakeString(body, "personality")
+	}
+	if _, ok := body["host_user"]; ok {
+		h.HostUser = fakeString(body, "host_user")
+	}
+	if _, ok := body["host_password"]; ok {
+		h.HostPassword = fakeString(body, "host_password")
+	}
+	if _, ok := body["target_user"]; ok {
+		h.TargetUser = fakeString(body, "target_user")
+	}
+	if _, ok := body["target_password"]; ok {
+		h.TargetPassword = fakeString(body, "target_password")
+	}
+	return true
+}
+
+func (fa *fakeFlashArray) handleHostVolume(w http.ResponseWriter, r *http.Request, h *fakeHost, parts []string, params map[string]string, body map[string]interface{}) {
+	if len(parts) == 2 {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		out := []map[string]interface{}{}
+		if params["shared"] != "true" {
+			for _, vol := range fakeSortedKeys(h.Volumes) {
+				out = append(out, map[string]interface{}{"name": h.Name, "vol": vol, "lun": h.Volumes[vol], "hgroup": nil})
+			}
+		}
+		if hg, ok := fa.hgroups[h.Hgroup]; ok && params["private"] != "true" {
+			for _, vol := range fakeSortedKeys(hg.Volumes) {
+				out = append(out, map[string]interface{}{"name": h.Name, "vol": vol, "lun": hg.Volumes[vol], "hgroup": hg.Name})
+			}
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	vol := parts[2]
+	switch r.Method {
+	case http.MethodPost:
+		if !fa.connectableVolume(w, vol) {
+			return
+		}
+		if _, ok := h.Volumes[vol]; ok {
+			fakeRespondError(w, http.StatusBadRequest, vol, "Connection already exists.")
+			return
+		}
+		if hg, ok := fa.hgroups[h.Hgroup]; ok {
+			if _, ok := hg.Volumes[vol]; ok {
+				fakeRespondError(w, http.StatusBadRequest, vol, "Volume is already connected through the host group.")
+				return
+			}
+		}
+		used := fa.hostLuns(h)
+		lun, ok := fakeInt(body, "lun")
+		if ok {
+			if lun < 1 || lun > 16383 || used[lun] {
+				fakeRespondError(w, http.StatusBadRequest, vol, "LUN is already in use or invalid.")
+				return
+			}
+		} else {
+			for lun = 1; used[lun]; lun++ {
+			}
+		}
+		h.Volumes[vol] = lun
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"name": h.Name, "vol": vol, "lun": lun})
+	case http.MethodDelete:
+		lun, ok := h.Volumes[vol]
+		if !ok {
+			fakeRespondError(w, http.StatusBadRequest, vol, "Connection does not exist.")
+			return
+		}
+		delete(h.Volumes, vol)
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"name": h.Name, "vol": vol, "lun": lun})
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+	}
+}
+
+func (fa *fakeFlashArray) hgroupView(hg *fakeHgroup) map[string]interface{} {
+	return map[string]interface{}{"name": hg.Name, "hosts": hg.Hosts}
+}
+
+// setHgroupHosts replaces the members of a host group.
+func (fa *fakeFlashArray) setHgroupHosts(w http.ResponseWriter, hg *fakeHgroup, hosts []string) bool {
+	for _, name := range hosts {
+		h, ok := fa.hosts[name]
+		if !ok {
+			fakeRespondError(w, http.StatusBadRequest, name, "Host does not exist.")
+			return false
+		}
+		if h.Hgroup != "" && h.Hgroup != hg.Name {
+			fakeRespondError(w, http.StatusBadRequest, name, "Host already belongs to a host group.")
+			return false
+		}
+	}
+	for _, name := range hg.Hosts {
+		if h, ok := fa.hosts[name]; ok {
+			h.Hgroup = ""
+		}
+	}
+	for _, name := range hosts {
+		fa.hosts[name].Hgroup = hg.Name
+	}
+	hg.Hosts = hosts
+	return true
+}
+
+func (fa *fakeFlashArray) handleHgroup(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	if rest == "" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		out := []map[string]interface{}{}
+		for _, hg := range fa.sortedHgroups() {
+			out = append(out, fa.hgroupView(hg))
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	parts := strings.SplitN(rest, "/", 3)
+	name := parts[0]
+	hg, exists := fa.hgroups[name]
+	if r.Method == http.MethodPost && len(parts) == 1 {
+		if exists {
+			fakeRespondError(w, http.StatusBadRequest, name, "Host group already exists.")
+			return
+		}
+		hg = &fakeHgroup{Name: name, Hosts: []string{}, Volumes: make(map[string]int)}
+		if !fa.setHgroupHosts(w, hg, fakeStrings(body, "hostlist")) {
+			return
+		}
+		fa.hgroups[name] = hg
+		fakeRespond(w, http.StatusOK, fa.hgroupView(hg))
+		return
+	}
+	if !exists {
+		fakeRespondError(w, http.StatusBadRequest, name, "Host group does not exist.")
+		return
+	}
+
+	if len(parts) > 1 {
+		switch parts[1] {
+		case "volume":
+			fa.handleHgroupVolume(w, r, hg, parts, body)
+		case "pgroup":
+			if len(parts) != 3 {
+				fakeRespondError(w, http.StatusNotFound, "", "Not found.")
+				return
+			}
+			fa.handleMemberPgroup(w, r, "hgroup", name, parts[2])
+		default:
+			fakeRespondError(w, http.StatusNotFound, "", "Not found.")
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		hosts := hg.Hosts
+		if _, ok := body["hostlist"]; ok {
+			hosts = fakeStrings(body, "hostlist")
+		}
+		for _, h := range fakeStrings(body, "addhostlist") {
+			if !stringInSlice(h, hosts) {
+				hosts = append(hosts, h)
+			}
+		}
+		for _, h := range fakeStrings(body, "remhostlist") {
+			hosts = fakeRemoveString(hosts, h)
+		}
+		if !fa.setHgroupHosts(w, hg, append([]string{}, hosts...)) {
+			return
+		}
+		if n, ok := body["name"]; ok && n.(string) != name {
+			newName := n.(string)
+			if _, ok := fa.hgroups[newName]; ok {
+				fakeRespondError(w, http.StatusBadRequest, newName, "Host group already exists.")
+				return
+			}
+			delete(fa.hgroups, name)
+			hg.Name = newName
+			fa.hgroups[newName] = hg
+			for _, h := range hg.Hosts {
+				fa.hosts[h].Hgroup = newName
+			}
+			for _, pg := range fa.pgroups {
+				pg.Hgroups = fakeReplaceString(pg.Hgroups, name, newName)
+			}
+		}
+	case http.MethodDelete:
+		if len(hg.Hosts) > 0 {
+			fakeRespondError(w, http.StatusBadRequest, name, "Host group has member hosts.")
+			return
+		}
+		if len(hg.Volumes) > 0 {
+			fakeRespondError(w, http.StatusBadRequest, name, "Host group has connected volumes.")
+			return
+		}
+		delete(fa.hgroups, name)
+		for _, pg := range fa.pgroups {
+			pg.Hgroups = fakeRemoveString(pg.Hgroups, name)
+		}
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+		return
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, fa.hgroupView(hg))
+}
+
+func (fa *fakeFlashArray) handleHgroupVolume(w http.ResponseWriter, r *http.Request, hg *fakeHgroup, parts []string, body map[string]interface{}) {
+	if len(parts) == 2 {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		out := []map[string]interface{}{}
+		for _, vol := range fakeSortedKeys(hg.Volumes) {
+			out = append(out, map[string]interface{}{"name": hg.Name, "vol": vol, "lun": hg.Volumes[vol]})
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	vol := parts[2]
+	switch r.Method {
+	case http.MethodPost:
+		if !fa.connectableVolume(w, vol) {
+			return
+		}
+		if _, ok := hg.Volumes[vol]; ok {
+			fakeRespondError(w, http.StatusBadRequest, vol, "Connection already exists.")
+			return
+		}
+		used := make(map[int]bool)
+		for _, lun := range hg.Volumes {
+			used[lun] = true
+		}
+		for _, name := range hg.Hosts {
+			h := fa.hosts[name]
+			if _, ok := h.Volumes[vol]; ok {
+				fakeRespondError(w, http.StatusBadRequest, vol, "Volume is already connected to a member host.")
+				return
+			}
+			for _, lun := range h.Volumes {
+				used[lun] = true
+			}
+		}
+		lun, ok := fakeInt(body, "lun")
+		if ok {
+			if lun < 1 || lun > 16383 || used[lun] {
+				fakeRespondError(w, http.StatusBadRequest, vol, "LUN is already in use or invalid.")
+				return
+			}
+		} else {
+			for lun = fakeMaxLun; used[lun]; lun-- {
+			}
+		}
+		hg.Volumes[vol] = lun
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"name": hg.Name, "vol": vol, "lun": lun})
+	case http.MethodDelete:
+		lun, ok := hg.Volumes[vol]
+		if !ok {
+			fakeRespondError(w, http.StatusBadRequest, vol, "Connection does not exist.")
+			return
+		}
+		delete(hg.Volumes, vol)
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"name": hg.Name, "vol": vol, "lun": lun})
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+	}
+}
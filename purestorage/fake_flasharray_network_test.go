@@ -0,0 +1,384 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type fakeSubnet struct {
+	Name    string
+	Prefix  string
+	Vlan    int
+	Gateway string
+	Mtu     int
+	Enabled bool
+}
+
+type fakeInterface struct {
+	Name     string
+	Address  string
+	Netmask  string
+	Gateway  string
+	Mtu      int
+	Enabled  bool
+	Subnet   string
+	Services []string
+	Slaves   []string
+	Hwaddr   string
+	Speed    int
+
+	// Vlan is set on VLAN interfaces, which are created and deleted through
+	// network/vif.
+	Vlan bool
+}
+
+// fakeDefaultInterfaces returns the physical interfaces of a two controller
+// array with management and iSCSI ports.
+func fakeDefaultInterfaces() map[string]*fakeInterface {
+	interfaces := map[string]*fakeInterface{
+		"vir0": {Name: "vir0", Address: "10.0.0.10", Netmask: "255.255.255.0", Gateway: "10.0.0.1", Mtu: 1500, Enabled: true, Services: []string{"management"}, Hwaddr: "22:a6:a9:0b:00:00"},
+	}
+	for i, ct := range []string{"ct0", "ct1"} {
+		interfaces[ct+".eth0"] = &fakeInterface{
+			Name: ct + ".eth0", Address: "10.0.0." + strconv.Itoa(11+i), Netmask: "255.255.255.0", Gateway: "10.0.0.1",
+			Mtu: 1500, Enabled: true, Services: []string{"management"}, Hwaddr: "24:a9:37:00:0" + strconv.Itoa(i) + ":00", Speed: 1000000000,
+		}
+		for j, port := range []string{"eth2", "eth3"} {
+			interfaces[ct+"."+port] = &fakeInterface{
+				Name: ct + "." + port, Mtu: 1500, Services: []string{"iscsi"},
+				Hwaddr: "24:a9:37:00:0" + strconv.Itoa(i) + ":0" + strconv.Itoa(j+2), Speed: 10000000000,
+			}
+		}
+	}
+	return interfaces
+}
+
+func (s *fakeSubnet) view(fa *fakeFlashArray) map[string]interface{} {
+	var interfaces, services []string
+	for _, name := range fakeSortedInterfaces(fa.interfaces) {
+		iface := fa.interfaces[name]
+		if iface.Subnet != s.Name {
+			continue
+		}
+		interfaces = append(interfaces, name)
+		for _, service := range iface.Services {
+			if !stringInSlice(service, services) {
+				services = append(services, service)
+			}
+		}
+	}
+	return map[string]interface{}{
+		"name":       s.Name,
+		"prefix":     s.Prefix,
+		"vlan":       s.Vlan,
+		"gateway":    fakeOptional(s.Gateway),
+		"mtu":        s.Mtu,
+		"enabled":    s.Enabled,
+		"interfaces": fakeNullable(interfaces),
+		"services":   fakeNullable(services),
+	}
+}
+
+func (iface *fakeInterface) view() map[string]interface{} {
+	return map[string]interface{}{
+		"name":     iface.Name,
+		"address":  fakeOptional(iface.Address),
+		"netmask":  fakeOptional(iface.Netmask),
+		"gateway":  fakeOptional(iface.Gateway),
+		"mtu":      iface.Mtu,
+		"enabled":  iface.Enabled,
+		"subnet":   fakeOptional(iface.Subnet),
+		"services": iface.Services,
+		"slaves":   iface.Slaves,
+		"hwaddr":   iface.Hwaddr,
+		"speed":    iface.Speed,
+	}
+}
+
+func fakeSortedInterfaces(m map[string]*fakeInterface) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fakeNetmask returns the netmask of an IPv4 CIDR prefix in dotted notation.
+func fakeNetmask(prefix string) string {
+	_, ipnet, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return ""
+	}
+	return net.IP(ipnet.Mask).String()
+}
+
+func (fa *fakeFlashArray) handleSubnet(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	if rest == "" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		var names []string
+		for name := range fa.subnets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out := []map[string]interface{}{}
+		for _, name := range names {
+			out = append(out, fa.subnets[name].view(fa))
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	name := rest
+	s, exists := fa.subnets[name]
+	if r.Method == http.MethodPost {
+		if exists {
+			fakeRespondError(w, http.StatusBadRequest, name, "Subnet already exists.")
+			return
+		}
+		s = &fakeSubnet{Name: name, Mtu: 1500, Enabled: true}
+		if _, ok := body["prefix"]; !ok {
+			fakeRespondError(w, http.StatusBadRequest, name, "Missing prefix.")
+			return
+		}
+		if !fa.setSubnet(w, s, body) {
+			return
+		}
+		fa.subnets[name] = s
+		fakeRespond(w, http.StatusOK, s.view(fa))
+		return
+	}
+	if !exists {
+		fakeRespondError(w, http.StatusBadRequest, name, "Subnet does not exist.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if !fa.setSubnet(w, s, body) {
+			return
+		}
+		if n, ok := body["name"]; ok && n.(string) != name {
+			newName := n.(string)
+			if _, ok := fa.subnets[newName]; ok {
+				fakeRespondError(w, http.StatusBadRequest, newName, "Subnet already exists.")
+				return
+			}
+			delete(fa.subnets, name)
+			s.Name = newName
+			fa.subnets[newName] = s
+			for _, iface := range fa.interfaces {
+				if iface.Subnet == name {
+					iface.Subnet = newName
+				}
+			}
+		}
+	case http.MethodDelete:
+		for _, iface := range fa.interfaces {
+			if iface.Subnet == name {
+				fakeRespondError(w, http.StatusBadRequest, name, "Subnet has interfaces.")
+				return
+			}
+		}
+		delete(fa.subnets, name)
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+		return
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, s.view(fa))
+}
+
+// setSubnet validates and applies the subnet attributes in body.
+func (fa *fakeFlashArray) setSubnet(w http.ResponseWriter, s *fakeSubnet, body map[string]interface{}) bool {
+	prefix, vlan, gateway, mtu, enabled := s.Prefix, s.Vlan, s.Gateway, s.Mtu, s.Enabled
+	if _, ok := body["prefix"]; ok {
+		prefix = fakeString(body, "prefix")
+	}
+	if v, ok := fakeInt(body, "vlan"); ok {
+		vlan = v
+	}
+	if _, ok := body["gateway"]; ok {
+		gateway = fakeString(body, "gateway")
+	}
+	if v, ok := fakeInt(body, "mtu"); ok {
+		mtu = v
+	}
+	if _, ok := body["enabled"]; ok {
+		enabled = fakeBool(body, "enabled")
+	}
+
+	_, ipnet, err := net.ParseCIDR(prefix)
+	if err != nil {
+		fakeRespondError(w, http.StatusBadRequest, prefix, "Invalid prefix.")
+		return false
+	}
+	if gateway != "" && !ipnet.Contains(net.ParseIP(gateway)) {
+		fakeRespondError(w, http.StatusBadRequest, gateway, "Gateway is not in the subnet.")
+		return false
+	}
+	if vlan < 0 || vlan > 4094 {
+		fakeRespondError(w, http.StatusBadRequest, strconv.Itoa(vlan), "Invalid VLAN.")
+		return false
+	}
+	if mtu < 568 || mtu > 9000 {
+		fakeRespondError(w, http.StatusBadRequest, strconv.Itoa(mtu), "Invalid MTU.")
+		return false
+	}
+	if vlan != s.Vlan {
+		for _, iface := range fa.interfaces {
+			if iface.Subnet == s.Name && iface.Vlan {
+				fakeRespondError(w, http.StatusBadRequest, s.Name, "Subnet has VLAN interfaces.")
+				return false
+			}
+		}
+	}
+
+	s.Prefix, s.Vlan, s.Gateway, s.Mtu, s.Enabled = ipnet.String(), vlan, gateway, mtu, enabled
+	for _, iface := range fa.interfaces {
+		if iface.Subnet == s.Name {
+			iface.Netmask, iface.Gateway, iface.Mtu = fakeNetmask(s.Prefix), s.Gateway, s.Mtu
+		}
+	}
+	return true
+}
+
+func (fa *fakeFlashArray) handleNetwork(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	if rest == "" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		out := []map[string]interface{}{}
+		for _, name := range fakeSortedInterfaces(fa.interfaces) {
+			out = append(out, fa.interfaces[name].view())
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+	if strings.HasPrefix(rest, "vif/") {
+		fa.handleVlanInterface(w, r, strings.TrimPrefix(rest, "vif/"), body)
+		return
+	}
+
+	name := rest
+	iface, ok := fa.interfaces[name]
+	if !ok {
+		fakeRespondError(w, http.StatusBadRequest, name, "Interface does not exist.")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		address, netmask, gateway := iface.Address, iface.Netmask, iface.Gateway
+		for key, value := range map[string]*string{"address": &address, "netmask": &netmask, "gateway": &gateway} {
+			if _, ok := body[key]; !ok {
+				continue
+			}
+			*value = fakeString(body, key)
+			if *value != "" && net.ParseIP(*value).To4() == nil {
+				fakeRespondError(w, http.StatusBadRequest, *value, "Invalid "+key+".")
+				return
+			}
+		}
+		if iface.Subnet != "" && (netmask != iface.Netmask || gateway != iface.Gateway) {
+			fakeRespondError(w, http.StatusBadRequest, name, "Netmask and gateway are set by the subnet.")
+			return
+		}
+		mtu := iface.Mtu
+		if v, ok := fakeInt(body, "mtu"); ok {
+			mtu = v
+		}
+		if mtu < 568 || mtu > 9000 {
+			fakeRespondError(w, http.StatusBadRequest, strconv.Itoa(mtu), "Invalid MTU.")
+			return
+		}
+		iface.Address, iface.Netmask, iface.Gateway, iface.Mtu = address, netmask, gateway, mtu
+		if _, ok := body["enabled"]; ok {
+			iface.Enabled = fakeBool(body, "enabled")
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, iface.view())
+}
+
+// handleVlanInterface creates and deletes VLAN interfaces. A VLAN interface
+// is named after its physical interface and the VLAN of its subnet, e.g.
+// ct0.eth2.100.
+func (fa *fakeFlashArray) handleVlanInterface(w http.ResponseWriter, r *http.Request, name string, body map[string]interface{}) {
+	iface, exists := fa.interfaces[name]
+	switch r.Method {
+	case http.MethodPost:
+		if exists {
+			fakeRespondError(w, http.StatusBadRequest, name, "Interface already exists.")
+			return
+		}
+		s, ok := fa.subnets[fakeString(body, "subnet")]
+		if !ok {
+			fakeRespondError(w, http.StatusBadRequest, fakeString(body, "subnet"), "Subnet does not exist.")
+			return
+		}
+		i := strings.LastIndex(name, ".")
+		var parent *fakeInterface
+		if i > 0 {
+			parent = fa.interfaces[name[:i]]
+		}
+		if parent == nil || parent.Vlan {
+			fakeRespondError(w, http.StatusBadRequest, name, "Invalid VLAN interface name.")
+			return
+		}
+		if vlan, err := strconv.Atoi(name[i+1:]); err != nil || s.Vlan == 0 || vlan != s.Vlan {
+			fakeRespondError(w, http.StatusBadRequest, name, "VLAN interface does not match the VLAN of the subnet.")
+			return
+		}
+		iface = &fakeInterface{
+			Name:     name,
+			Netmask:  fakeNetmask(s.Prefix),
+			Gateway:  s.Gateway,
+			Mtu:      s.Mtu,
+			Enabled:  true,
+			Subnet:   s.Name,
+			Services: parent.Services,
+			Hwaddr:   parent.Hwaddr,
+			Speed:    parent.Speed,
+			Vlan:     true,
+		}
+		fa.interfaces[name] = iface
+	case http.MethodDelete:
+		if !exists || !iface.Vlan {
+			fakeRespondError(w, http.StatusBadRequest, name, "VLAN interface does not exist.")
+			return
+		}
+		delete(fa.interfaces, name)
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, iface.view())
+}
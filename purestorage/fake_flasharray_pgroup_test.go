@@ -0,0 +1,540 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type fakePgroup struct {
+	Name      string
+	Source    string
+	Hosts     []string
+	Volumes   []string
+	Hgroups   []string
+	Targets   []map[string]interface{}
+	Destroyed bool
+
+	// Retention and schedule settings, keyed by their API names.
+	Retention map[string]interface{}
+	Schedule  map[string]interface{}
+
+	// Snapshots are the protection group snapshots, keyed by name.
+	Snapshots map[string]*fakePgroupSnapshot
+}
+
+type fakePgroupSnapshot struct {
+	Name           string
+	Source         string
+	Created        string
+	ApplyRetention bool
+	ReplicateNow   bool
+	Destroyed      bool
+}
+
+func (snap *fakePgroupSnapshot) view() map[string]interface{} {
+	out := map[string]interface{}{
+		"name":    snap.Name,
+		"source":  snap.Source,
+		"created": snap.Created,
+	}
+	if snap.Destroyed {
+		out["time_remaining"] = fakeEradicationSeconds
+	}
+	return out
+}
+
+func (pg *fakePgroup) view(params map[string]string) map[string]interface{} {
+	var out map[string]interface{}
+	switch {
+	case params["schedule"] == "true":
+		out = map[string]interface{}{"name": pg.Name}
+		for k, v := range pg.Schedule {
+			out[k] = v
+		}
+	case params["retention"] == "true":
+		out = map[string]interface{}{"name": pg.Name}
+		for k, v := range pg.Retention {
+			out[k] = v
+		}
+	default:
+		var targets interface{}
+		if len(pg.Targets) > 0 {
+			targets = pg.Targets
+		}
+		out = map[string]interface{}{
+			"name":    pg.Name,
+			"source":  pg.Source,
+			"hosts":   fakeNullable(pg.Hosts),
+			"volumes": fakeNullable(pg.Volumes),
+			"hgroups": fakeNullable(pg.Hgroups),
+			"targets": targets,
+		}
+	}
+	if pg.Destroyed {
+		out["time_remaining"] = fakeEradicationSeconds
+	}
+	return out
+}
+
+func (fa *fakeFlashArray) handlePgroup(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	params := fakeParams(r)
+	if rest == "" {
+		switch r.Method {
+		case http.MethodGet:
+			if params["snap"] == "true" {
+				fa.listPgroupSnapshots(w, params)
+				return
+			}
+			var names []string
+			for name := range fa.pgroups {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			out := []map[string]interface{}{}
+			for _, name := range names {
+				if pg := fa.pgroups[name]; fakePendingVisible(pg.Destroyed, params) {
+					out = append(out, pg.view(params))
+				}
+			}
+			fakeRespond(w, http.StatusOK, out)
+		case http.MethodPost:
+			if fakeBool(body, "snap") || fakeString(body, "action") == "send" {
+				fa.createPgroupSnapshots(w, body)
+				return
+			}
+			fakeRespondError(w, http.StatusBadRequest, "", "Invalid request.")
+		default:
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		}
+		return
+	}
+
+	name := rest
+	if i := strings.Index(name, "."); i >= 0 {
+		fa.handlePgroupSnapshot(w, r, name[:i], name, body)
+		return
+	}
+	pg, exists := fa.pgroups[name]
+	if r.Method == http.MethodPost {
+		if exists {
+			fakeRespondError(w, http.StatusBadRequest, name, "Protection group already exists.")
+			return
+		}
+		pg = &fakePgroup{
+			Name:      name,
+			Source:    fa.ArrayName,
+			Snapshots: make(map[string]*fakePgroupSnapshot),
+			Retention: map[string]interface{}{
+				"all_for":        86400,
+				"days":           7,
+				"per_day":        4,
+				"target_all_for": 86400,
+				"target_days":    7,
+				"target_per_day": 4,
+			},
+			Schedule: map[string]interface{}{
+				"replicate_at":        nil,
+				"replicate_blackout":  nil,
+				"replicate_enabled":   false,
+				"replicate_frequency": 14400,
+				"snap_at":             nil,
+				"snap_enabled":        false,
+				"snap_frequency":      3600,
+			},
+		}
+		if !fa.setPgroupMembers(w, pg, body) {
+			return
+		}
+		fa.pgroups[name] = pg
+		fakeRespond(w, http.StatusOK, pg.view(nil))
+		return
+	}
+	if !exists {
+		fakeRespondError(w, http.StatusBadRequest, name, "Protection group does not exist.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if !fakePendingVisible(pg.Destroyed, params) {
+			fakeRespondError(w, http.StatusBadRequest, name, "Protection group does not exist.")
+			return
+		}
+	case http.MethodPut:
+		if fakeString(body, "action") == "recover" {
+			if !pg.Destroyed {
+				fakeRespondError(w, http.StatusBadRequest, name, "Protection group is not destroyed.")
+				return
+			}
+			pg.Destroyed = false
+			break
+		}
+		if pg.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Protection group has been destroyed.")
+			return
+		}
+		if !fa.setPgroupMembers(w, pg, body) {
+			return
+		}
+		for k := range pg.Retention {
+			if v, ok := fakeInt(body, k); ok {
+				pg.Retention[k] = v
+			}
+		}
+		for k := range pg.Schedule {
+			v, ok := body[k]
+			if !ok {
+				continue
+			}
+			if i, ok := fakeInt(body, k); ok {
+				v = i
+			}
+			pg.Schedule[k] = v
+		}
+		if n, ok := body["name"]; ok && n.(string) != name {
+			newName := n.(string)
+			if _, ok := fa.pgroups[newName]; ok {
+				fakeRespondError(w, http.StatusBadRequest, newName, "Protection group already exists.")
+				return
+			}
+			delete(fa.pgroups, name)
+			pg.Name = newName
+			fa.pgroups[newName] = pg
+		}
+	case http.MethodDelete:
+		if fakeBool(body, "eradicate") {
+			if !pg.Destroyed {
+				fakeRespondError(w, http.StatusBadRequest, name, "Protection group must be destroyed before it can be eradicated.")
+				return
+			}
+			for _, snap := range pg.Snapshots {
+				fa.eradicatePgroupSnapshot(pg, snap)
+			}
+			delete(fa.pgroups, name)
+			fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+			return
+		}
+		if pg.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Protection group has been destroyed.")
+			return
+		}
+		pg.Destroyed = true
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, pg.view(params))
+}
+
+// setPgroupMembers applies the member and target lists in body. A protection
+// group can only contain one type of member.
+func (fa *fakeFlashArray) setPgroupMembers(w http.ResponseWriter, pg *fakePgroup, body map[string]interface{}) bool {
+	hosts, volumes, hgroups := pg.Hosts, pg.Volumes, pg.Hgroups
+	if _, ok := body["hostlist"]; ok {
+		hosts = fakeStrings(body, "hostlist")
+	}
+	if _, ok := body["vollist"]; ok {
+		volumes = fakeStrings(body, "vollist")
+	}
+	if _, ok := body["hgrouplist"]; ok {
+		hgroups = fakeStrings(body, "hgrouplist")
+	}
+	for _, name := range hosts {
+		if _, ok := fa.hosts[name]; !ok {
+			fakeRespondError(w, http.StatusBadRequest, name, "Host does not exist.")
+			return false
+		}
+	}
+	for _, name := range volumes {
+		if v, ok := fa.volumes[name]; !ok || v.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume does not exist.")
+			return false
+		}
+	}
+	for _, name := range hgroups {
+		if _, ok := fa.hgroups[name]; !ok {
+			fakeRespondError(w, http.StatusBadRequest, name, "Host group does not exist.")
+			return false
+		}
+	}
+	types := 0
+	for _, l := range [][]string{hosts, volumes, hgroups} {
+		if len(l) > 0 {
+			types++
+		}
+	}
+	if types > 1 {
+		fakeRespondError(w, http.StatusBadRequest, pg.Name, "Protection group can only contain one type of member.")
+		return false
+	}
+
+	targets := pg.Targets
+	var added []string
+	if _, ok := body["targetlist"]; ok {
+		targets, added = nil, fakeStrings(body, "targetlist")
+	}
+	for _, name := range fakeStrings(body, "remtargetlist") {
+		i := fakeTargetIndex(targets, name)
+		if i < 0 {
+			fakeRespondError(w, http.StatusBadRequest, name, "Array is not a target of the protection group.")
+			return false
+		}
+		targets = append(targets[:i:i], targets[i+1:]...)
+	}
+	added = append(added, fakeStrings(body, "addtargetlist")...)
+	for _, name := range added {
+		if !stringInSlice(name, fa.connectedArrays) {
+			fakeRespondError(w, http.StatusBadRequest, name, "Array is not connected.")
+			return false
+		}
+		if fakeTargetIndex(targets, name) >= 0 {
+			fakeRespondError(w, http.StatusBadRequest, name, "Array is already a target of the protection group.")
+			return false
+		}
+		targets = append(targets, map[string]interface{}{"name": name, "allowed": false})
+	}
+	if allowed, ok := body["allowed"].(bool); ok {
+		// Replication is allowed on the target array, for the protection
+		// groups replicated from another array.
+		i := fakeTargetIndex(targets, fa.ArrayName)
+		if pg.Source == fa.ArrayName || i < 0 {
+			fakeRespondError(w, http.StatusBadRequest, pg.Name, "Protection group is not replicated to this array.")
+			return false
+		}
+		targets[i] = map[string]interface{}{"name": fa.ArrayName, "allowed": allowed}
+	}
+
+	pg.Hosts, pg.Volumes, pg.Hgroups, pg.Targets = hosts, volumes, hgroups, targets
+	return true
+}
+
+// fakeTargetIndex returns the index of the target array name in targets, or
+// -1 if it is not a target.
+func fakeTargetIndex(targets []map[string]interface{}, name string) int {
+	for i, t := range targets {
+		if t["name"] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// handleMemberPgroup adds or removes a host or host group from a protection group.
+func (fa *fakeFlashArray) handleMemberPgroup(w http.ResponseWriter, r *http.Request, kind string, name string, pgroup string) {
+	pg, ok := fa.pgroups[pgroup]
+	if !ok || pg.Destroyed {
+		fakeRespondError(w, http.StatusBadRequest, pgroup, "Protection group does not exist.")
+		return
+	}
+	members, others := &pg.Hosts, len(pg.Volumes)+len(pg.Hgroups)
+	if kind == "hgroup" {
+		members, others = &pg.Hgroups, len(pg.Volumes)+len(pg.Hosts)
+	}
+	switch r.Method {
+	case http.MethodPost:
+		if others > 0 {
+			fakeRespondError(w, http.StatusBadRequest, pgroup, "Protection group can only contain one type of member.")
+			return
+		}
+		if stringInSlice(name, *members) {
+			fakeRespondError(w, http.StatusBadRequest, name, "Already a member of the protection group.")
+			return
+		}
+		*members = append(*members, name)
+	case http.MethodDelete:
+		if !stringInSlice(name, *members) {
+			fakeRespondError(w, http.StatusBadRequest, name, "Not a member of the protection group.")
+			return
+		}
+		*members = fakeRemoveString(*members, name)
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name, "protection_group": pgroup})
+}
+
+// pgroupSnapshotVolumes returns the volumes protected by a protection group.
+func (fa *fakeFlashArray) pgroupSnapshotVolumes(pg *fakePgroup) []string {
+	volumes := append([]string{}, pg.Volumes...)
+	hosts := append([]string{}, pg.Hosts...)
+	for _, name := range pg.Hgroups {
+		if hg, ok := fa.hgroups[name]; ok {
+			hosts = append(hosts, hg.Hosts...)
+			for vol := range hg.Volumes {
+				volumes = append(volumes, vol)
+			}
+		}
+	}
+	for _, name := range hosts {
+		if h, ok := fa.hosts[name]; ok {
+			for vol := range h.Volumes {
+				volumes = append(volumes, vol)
+			}
+		}
+	}
+	sort.Strings(volumes)
+	var out []string
+	for _, vol := range volumes {
+		if !stringInSlice(vol, out) {
+			out = append(out, vol)
+		}
+	}
+	return out
+}
+
+func (fa *fakeFlashArray) createPgroupSnapshots(w http.ResponseWriter, body map[string]interface{}) {
+	sources := fakeStrings(body, "source")
+	suffix := fakeString(body, "suffix")
+	replicateNow := fakeBool(body, "replicate_now")
+	for _, source := range sources {
+		pg, ok := fa.pgroups[source]
+		if !ok || pg.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, source, "Protection group does not exist.")
+			return
+		}
+		if replicateNow && len(pg.Targets) == 0 {
+			fakeRespondError(w, http.StatusBadRequest, source, "Protection group does not have any targets.")
+			return
+		}
+	}
+	out := []map[string]interface{}{}
+	for _, source := range sources {
+		pg := fa.pgroups[source]
+		s := suffix
+		if s == "" {
+			fa.serial++
+			s = fmt.Sprintf("%d", fa.serial)
+		}
+		name := source + "." + s
+		if _, ok := pg.Snapshots[name]; ok {
+			fakeRespondError(w, http.StatusBadRequest, name, "Snapshot already exists.")
+			return
+		}
+		snap := &fakePgroupSnapshot{
+			Name:           name,
+			Source:         source,
+			Created:        fakeNow(),
+			ApplyRetention: fakeBool(body, "apply_retention"),
+			ReplicateNow:   replicateNow,
+		}
+		pg.Snapshots[name] = snap
+		for _, vol := range fa.pgroupSnapshotVolumes(pg) {
+			v := fa.volumes[vol]
+			fa.snapshots[name+"."+vol] = &fakeVolume{
+				Name:    name + "." + vol,
+				Source:  vol,
+				Serial:  fa.nextSerial(),
+				Size:    v.Size,
+				Created: snap.Created,
+			}
+		}
+		out = append(out, snap.view())
+	}
+	fakeRespond(w, http.StatusOK, out)
+}
+
+// listPgroupSnapshots lists the protection group snapshots, optionally
+// filtered by the names parameter.
+func (fa *fakeFlashArray) listPgroupSnapshots(w http.ResponseWriter, params map[string]string) {
+	var filter []string
+	if params["names"] != "" {
+		filter = strings.Split(params["names"], ",")
+	}
+	var names []string
+	snapshots := make(map[string]*fakePgroupSnapshot)
+	for _, pg := range fa.pgroups {
+		for name, snap := range pg.Snapshots {
+			if filter != nil && !stringInSlice(name, filter) {
+				continue
+			}
+			if fakePendingVisible(snap.Destroyed, params) {
+				names = append(names, name)
+				snapshots[name] = snap
+			}
+		}
+	}
+	sort.Strings(names)
+	out := []map[string]interface{}{}
+	for _, name := range names {
+		out = append(out, snapshots[name].view())
+	}
+	fakeRespond(w, http.StatusOK, out)
+}
+
+// handlePgroupSnapshot destroys, recovers and eradicates a protection group
+// snapshot together with its volume snapshots.
+func (fa *fakeFlashArray) handlePgroupSnapshot(w http.ResponseWriter, r *http.Request, pgroup string, name string, body map[string]interface{}) {
+	var snap *fakePgroupSnapshot
+	pg, ok := fa.pgroups[pgroup]
+	if ok {
+		snap = pg.Snapshots[name]
+	}
+	if snap == nil {
+		fakeRespondError(w, http.StatusBadRequest, name, "Protection group snapshot does not exist.")
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		if fakeString(body, "action") != "recover" || !snap.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Protection group snapshot is not destroyed.")
+			return
+		}
+		fa.destroyPgroupSnapshot(snap, false)
+	case http.MethodDelete:
+		if fakeBool(body, "eradicate") {
+			if !snap.Destroyed {
+				fakeRespondError(w, http.StatusBadRequest, name, "Protection group snapshot must be destroyed before it can be eradicated.")
+				return
+			}
+			fa.eradicatePgroupSnapshot(pg, snap)
+			fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+			return
+		}
+		if snap.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Protection group snapshot has been destroyed.")
+			return
+		}
+		fa.destroyPgroupSnapshot(snap, true)
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, snap.view())
+}
+
+func (fa *fakeFlashArray) destroyPgroupSnapshot(snap *fakePgroupSnapshot, destroyed bool) {
+	snap.Destroyed = destroyed
+	for name, v := range fa.snapshots {
+		if strings.HasPrefix(name, snap.Name+".") {
+			v.Destroyed = destroyed
+		}
+	}
+}
+
+func (fa *fakeFlashArray) eradicatePgroupSnapshot(pg *fakePgroup, snap *fakePgroupSnapshot) {
+	delete(pg.Snapshots, snap.Name)
+	for name := range fa.snapshots {
+		if strings.HasPrefix(name, snap.Name+".") {
+			delete(fa.snapshots, name)
+		}
+	}
+}
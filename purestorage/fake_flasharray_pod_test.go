@@ -0,0 +1,235 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type fakePod struct {
+	Name               string
+	Source             string
+	Arrays             []string
+	FailoverPreference []string
+	Destroyed          bool
+}
+
+func (pod *fakePod) view() map[string]interface{} {
+	arrays := []map[string]interface{}{}
+	for _, a := range pod.Arrays {
+		arrays = append(arrays, map[string]interface{}{
+			"name":            a,
+			"status":          "online",
+			"mediator_status": "online",
+			"frozen_at":       nil,
+		})
+	}
+	out := map[string]interface{}{
+		"name":                pod.Name,
+		"source":              fakeOptional(pod.Source),
+		"arrays":              arrays,
+		"failover_preference": pod.FailoverPreference,
+	}
+	if pod.Destroyed {
+		out["time_remaining"] = fakeEradicationSeconds
+	}
+	return out
+}
+
+// podVolumes returns the volumes in a pod that are not destroyed.
+func (fa *fakeFlashArray) podVolumes(name string) []string {
+	var volumes []string
+	for vname, v := range fa.volumes {
+		if strings.HasPrefix(vname, name+"::") && !v.Destroyed {
+			volumes = append(volumes, vname)
+		}
+	}
+	sort.Strings(volumes)
+	return volumes
+}
+
+func (fa *fakeFlashArray) handlePod(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	params := fakeParams(r)
+	if rest == "" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		var names []string
+		for name := range fa.pods {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out := []map[string]interface{}{}
+		for _, name := range names {
+			if pod := fa.pods[name]; fakePendingVisible(pod.Destroyed, params) {
+				out = append(out, pod.view())
+			}
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	parts := strings.SplitN(rest, "/", 3)
+	name := parts[0]
+	pod, exists := fa.pods[name]
+	if r.Method == http.MethodPost && len(parts) == 1 {
+		if exists {
+			fakeRespondError(w, http.StatusBadRequest, name, "Pod already exists.")
+			return
+		}
+		pod = &fakePod{Name: name, Arrays: []string{fa.ArrayName}, FailoverPreference: []string{}}
+		fa.pods[name] = pod
+		fakeRespond(w, http.StatusOK, pod.view())
+		return
+	}
+	if !exists {
+		fakeRespondError(w, http.StatusBadRequest, name, "Pod does not exist.")
+		return
+	}
+
+	if len(parts) > 1 {
+		if len(parts) != 3 || parts[1] != "array" {
+			fakeRespondError(w, http.StatusNotFound, "", "Not found.")
+			return
+		}
+		fa.handlePodArray(w, r, pod, parts[2])
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if !fakePendingVisible(pod.Destroyed, params) {
+			fakeRespondError(w, http.StatusBadRequest, name, "Pod does not exist.")
+			return
+		}
+	case http.MethodPut:
+		if fakeString(body, "action") == "recover" {
+			if !pod.Destroyed {
+				fakeRespondError(w, http.StatusBadRequest, name, "Pod is not destroyed.")
+				return
+			}
+			pod.Destroyed = false
+			break
+		}
+		if pod.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Pod has been destroyed.")
+			return
+		}
+		if _, ok := body["failover_preference"]; ok {
+			preference := fakeStrings(body, "failover_preference")
+			for _, a := range preference {
+				if !stringInSlice(a, pod.Arrays) {
+					fakeRespondError(w, http.StatusBadRequest, a, "Array is not a member of the pod.")
+					return
+				}
+			}
+			pod.FailoverPreference = preference
+		}
+		if n, ok := body["name"]; ok && n.(string) != name {
+			newName := n.(string)
+			if _, ok := fa.pods[newName]; ok {
+				fakeRespondError(w, http.StatusBadRequest, newName, "Pod already exists.")
+				return
+			}
+			delete(fa.pods, name)
+			pod.Name = newName
+			fa.pods[newName] = pod
+			var members []*fakeVolume
+			for vname, v := range fa.volumes {
+				if strings.HasPrefix(vname, name+"::") {
+					members = append(members, v)
+				}
+			}
+			for _, v := range members {
+				fa.renameVolume(v, newName+strings.TrimPrefix(v.Name, name))
+			}
+		}
+	case http.MethodDelete:
+		if fakeBool(body, "eradicate") {
+			if !pod.Destroyed {
+				fakeRespondError(w, http.StatusBadRequest, name, "Pod must be destroyed before it can be eradicated.")
+				return
+			}
+			for vname, v := range fa.volumes {
+				if strings.HasPrefix(vname, name+"::") {
+					fa.eradicateVolume(v)
+				}
+			}
+			delete(fa.pods, name)
+			fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+			return
+		}
+		if pod.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Pod has been destroyed.")
+			return
+		}
+		if len(pod.Arrays) > 1 {
+			fakeRespondError(w, http.StatusBadRequest, name, "Pod is stretched to other arrays.")
+			return
+		}
+		if len(fa.podVolumes(name)) > 0 {
+			fakeRespondError(w, http.StatusBadRequest, name, "Pod contains volumes.")
+			return
+		}
+		pod.Destroyed = true
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, pod.view())
+}
+
+// handlePodArray stretches a pod to, or unstretches it from, an array.
+func (fa *fakeFlashArray) handlePodArray(w http.ResponseWriter, r *http.Request, pod *fakePod, array string) {
+	if pod.Destroyed {
+		fakeRespondError(w, http.StatusBadRequest, pod.Name, "Pod has been destroyed.")
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		if !stringInSlice(array, fa.connectedArrays) {
+			fakeRespondError(w, http.StatusBadRequest, array, "Array is not connected.")
+			return
+		}
+		if stringInSlice(array, pod.Arrays) {
+			fakeRespondError(w, http.StatusBadRequest, array, "Pod is already stretched to the array.")
+			return
+		}
+		pod.Arrays = append(pod.Arrays, array)
+	case http.MethodDelete:
+		if !stringInSlice(array, pod.Arrays) {
+			fakeRespondError(w, http.StatusBadRequest, array, "Array is not a member of the pod.")
+			return
+		}
+		if len(pod.Arrays) == 1 {
+			fakeRespondError(w, http.StatusBadRequest, array, "Cannot remove the last array of a pod.")
+			return
+		}
+		pod.Arrays = fakeRemoveString(pod.Arrays, array)
+		pod.FailoverPreference = fakeRemoveString(pod.FailoverPreference, array)
+		if pod.FailoverPreference == nil {
+			pod.FailoverPreference = []string{}
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, map[string]interface{}{"name": pod.Name, "array": array})
+}
@@ -0,0 +1,149 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"net/http"
+	"sort"
+)
+
+type fakeSnmpManager struct {
+	Name              string
+	Host              string
+	Version           string
+	Notification      string
+	Community         string
+	User              string
+	AuthProtocol      string
+	AuthPassphrase    string
+	PrivacyProtocol   string
+	PrivacyPassphrase string
+}
+
+func (s *fakeSnmpManager) view() map[string]interface{} {
+	return map[string]interface{}{
+		"name":               s.Name,
+		"host":               s.Host,
+		"version":            s.Version,
+		"notification":       s.Notification,
+		"community":          fakeMasked(s.Community),
+		"user":               fakeOptional(s.User),
+		"auth_protocol":      fakeOptional(s.AuthProtocol),
+		"auth_passphrase":    fakeMasked(s.AuthPassphrase),
+		"privacy_protocol":   fakeOptional(s.PrivacyProtocol),
+		"privacy_passphrase": fakeMasked(s.PrivacyPassphrase),
+		"engine_id":          "80000d740300000000000000",
+	}
+}
+
+func (fa *fakeFlashArray) handleSnmp(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	if rest == "" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		var names []string
+		for name := range fa.snmpManagers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out := []map[string]interface{}{}
+		for _, name := range names {
+			out = append(out, fa.snmpManagers[name].view())
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	s, exists := fa.snmpManagers[rest]
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if exists {
+			fakeRespondError(w, http.StatusBadRequest, rest, "SNMP manager already exists.")
+			return
+		}
+		s = &fakeSnmpManager{Name: rest, Version: "v2c", Notification: "trap"}
+		if !fa.setSnmp(w, s, body) {
+			return
+		}
+		fa.snmpManagers[rest] = s
+		exists = true
+	case http.MethodPut:
+		if exists && !fa.setSnmp(w, s, body) {
+			return
+		}
+	case http.MethodDelete:
+		if exists {
+			delete(fa.snmpManagers, rest)
+			fakeRespond(w, http.StatusOK, map[string]interface{}{"name": rest})
+			return
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	if !exists {
+		fakeRespondError(w, http.StatusBadRequest, rest, "SNMP manager does not exist.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, s.view())
+}
+
+// setSnmp validates and applies the SNMP manager attributes in body.
+func (fa *fakeFlashArray) setSnmp(w http.ResponseWriter, s *fakeSnmpManager, body map[string]interface{}) bool {
+	n := *s
+	for key, field := range map[string]*string{
+		"host":               &n.Host,
+		"version":            &n.Version,
+		"notification":       &n.Notification,
+		"community":          &n.Community,
+		"user":               &n.User,
+		"auth_protocol":      &n.AuthProtocol,
+		"auth_passphrase":    &n.AuthPassphrase,
+		"privacy_protocol":   &n.PrivacyProtocol,
+		"privacy_passphrase": &n.PrivacyPassphrase,
+	} {
+		if _, ok := body[key]; ok {
+			*field = fakeString(body, key)
+		}
+	}
+
+	var msg string
+	switch {
+	case n.Host == "":
+		msg = "Host is required."
+	case !stringInSlice(n.Version, []string{"v2c", "v3"}):
+		msg = "Invalid version."
+	case !stringInSlice(n.Notification, []string{"trap", "inform"}):
+		msg = "Invalid notification."
+	case !stringInSlice(n.AuthProtocol, []string{"", "MD5", "SHA"}):
+		msg = "Invalid auth protocol."
+	case !stringInSlice(n.PrivacyProtocol, []string{"", "AES", "DES"}):
+		msg = "Invalid privacy protocol."
+	case n.Version == "v3" && n.User == "":
+		msg = "User is required for SNMP v3."
+	case n.PrivacyProtocol != "" && n.AuthProtocol == "":
+		msg = "Privacy requires authentication."
+	}
+	if msg != "" {
+		fakeRespondError(w, http.StatusBadRequest, s.Name, msg)
+		return false
+	}
+	*s = n
+	return true
+}
@@ -0,0 +1,807 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fakeRestVersions are the REST versions the fake array advertises on
+// /api/api_version.
+var fakeRestVersions = []string{"1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "1.9", "1.10", "1.11", "1.12", "1.13", "1.14", "1.15", "1.16"}
+
+// fakeFlashArray is an in-process stand-in for the FlashArray REST 1.x API.
+// It implements the endpoints the pugo client calls for the resources in this
+// provider, keeping all objects in memory. It is served over TLS because the
+// client always connects with https.
+type fakeFlashArray struct {
+	server *httptest.Server
+
+	mu sync.Mutex
+
+	ArrayName string
+	ArrayID   string
+	Username  string
+	Password  string
+	APIToken  string
+
+	// Array settings
+	ntpServers    []string
+	syslogServers []string
+	banner        string
+	idleTimeout   int
+	phonehome     bool
+	remoteAssist  bool
+	consoleLock   bool
+
+	sessions map[string]bool
+	serial   int
+
+	volumes   map[string]*fakeVolume
+	snapshots map[string]*fakeVolume
+	vgroups   map[string]*fakeVgroup
+	hosts     map[string]*fakeHost
+	hgroups   map[string]*fakeHgroup
+	pgroups   map[string]*fakePgroup
+	pods      map[string]*fakePod
+	alerts    map[string]bool
+	messages  []map[string]interface{}
+	dns       map[string]interface{}
+	smtp      map[string]interface{}
+
+	subnets    map[string]*fakeSubnet
+	interfaces map[string]*fakeInterface
+	admins     map[string]*fakeAdmin
+	dirsrv     *fakeDirsrv
+
+	snmpManagers map[string]*fakeSnmpManager
+	certs        map[string]*fakeCert
+
+	// connectedArrays are the names of arrays that can be used as
+	// protection group targets and pod members.
+	connectedArrays []string
+
+	// remoteArrays are the names of the arrays that can be connected,
+	// keyed by management address, and arrayConnections the connections
+	// made through array/connection, keyed by array name.
+	remoteArrays     map[string]string
+	arrayConnections map[string]*fakeArrayConnection
+
+	// failures are answered, in order, to the next requests other than
+	// logins instead of handling them.
+	failures []fakeFailure
+}
+
+// fakeFailure is an error injected with fail.
+type fakeFailure struct {
+	Status int
+	Msg    string
+}
+
+// fakeConnectionKey is the connection key of all remote arrays.
+const fakeConnectionKey = "6207d123-d123-0b5c-5fa1-95fabc5c7123"
+
+type fakeArrayConnection struct {
+	Name               string
+	ManagementAddress  string
+	ReplicationAddress string
+	Type               []string
+}
+
+func (c *fakeArrayConnection) view() map[string]interface{} {
+	return map[string]interface{}{
+		"array_name":          c.Name,
+		"management_address":  c.ManagementAddress,
+		"replication_address": c.ReplicationAddress,
+		"type":                c.Type,
+		"connected":           true,
+		"throttled":           false,
+		"version":             "5.3.2",
+	}
+}
+
+// fakeError is the error body returned by the array.
+type fakeError struct {
+	Ctx string `json:"ctx,omitempty"`
+	Msg string `json:"msg"`
+}
+
+// newFakeFlashArray starts a new fake array. The caller must call Close when done.
+func newFakeFlashArray() *fakeFlashArray {
+	fa := &fakeFlashArray{
+		ArrayName: "fakearray",
+		ArrayID:   "c2f7c58a-0bd8-4a6f-9b0b-6f2b7e1f3a10",
+		Username:  "pureuser",
+		Password:  "pureuser",
+		APIToken:  "3bdf3b60-f0c0-fa8a-83c1-b794ba8f562c",
+
+		ntpServers:  []string{"time.purestorage.com"},
+		idleTimeout: 30,
+		phonehome:   true,
+		sessions:    make(map[string]bool),
+		volumes:     make(map[string]*fakeVolume),
+		snapshots:   make(map[string]*fakeVolume),
+		vgroups:     make(map[string]*fakeVgroup),
+		hosts:       make(map[string]*fakeHost),
+		hgroups:     make(map[string]*fakeHgroup),
+		pgroups:     make(map[string]*fakePgroup),
+		pods:        make(map[string]*fakePod),
+		alerts:      map[string]bool{"flasharray-alerts@purestorage.com": true},
+		messages: []map[string]interface{}{
+			{
+				"id":             1,
+				"component_name": "ct0",
+				"component_type": "controller",
+				"details":        "",
+				"event":          "login",
+				"opened":         "2020-01-01T00:00:00Z",
+				"user":           "pureuser",
+			},
+		},
+		dns: map[string]interface{}{
+			"domain":      "",
+			"nameservers": []string{},
+		},
+		smtp: map[string]interface{}{
+			"relay_host":    "",
+			"sender_domain": "",
+			"user_name":     "",
+			"password":      "",
+		},
+		subnets:    make(map[string]*fakeSubnet),
+		interfaces: fakeDefaultInterfaces(),
+		dirsrv:     newFakeDirsrv(),
+
+		snmpManagers: make(map[string]*fakeSnmpManager),
+		certs:        fakeDefaultCerts(),
+
+		remoteArrays:     make(map[string]string),
+		arrayConnections: make(map[string]*fakeArrayConnection),
+	}
+	fa.admins = map[string]*fakeAdmin{
+		fa.Username: {Name: fa.Username, Role: "array_admin", Password: fa.Password, Token: fa.APIToken, TokenCreated: "2020-01-01T00:00:00Z"},
+	}
+	fa.server = httptest.NewTLSServer(fa)
+	return fa
+}
+
+// Target returns the address to use as the provider target.
+func (fa *fakeFlashArray) Target() string {
+	return fa.server.Listener.Addr().String()
+}
+
+// fail makes the fake array answer the next requests with the given errors.
+func (fa *fakeFlashArray) fail(failures ...fakeFailure) {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	fa.failures = append(fa.failures, failures...)
+}
+
+// expireSessions ends all the sessions, as the array does after the session
+// timeout.
+func (fa *fakeFlashArray) expireSessions() {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	fa.sessions = make(map[string]bool)
+}
+
+// Close shuts down the fake array.
+func (fa *fakeFlashArray) Close() {
+	fa.server.Close()
+}
+
+func (fa *fakeFlashArray) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+
+	if r.URL.Path == "/api/api_version" {
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"version": fakeRestVersions})
+		return
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/api/"), "/", 2)
+	if len(parts) != 2 || !stringInSlice(parts[0], fakeRestVersions) {
+		fakeRespondError(w, http.StatusNotFound, "", "Not found.")
+		return
+	}
+
+	body := make(map[string]interface{})
+	if r.Body != nil {
+		// An empty body is fine, so the decode error is ignored.
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	path := parts[1]
+	switch path {
+	case "auth/apitoken":
+		fa.handleAPIToken(w, r, body)
+		return
+	case "auth/session":
+		fa.handleSession(w, r, body)
+		return
+	}
+
+	if len(fa.failures) > 0 {
+		f := fa.failures[0]
+		fa.failures = fa.failures[1:]
+		fakeRespondError(w, f.Status, "", f.Msg)
+		return
+	}
+
+	if !fa.authenticated(r) {
+		fakeRespondError(w, http.StatusUnauthorized, "", "Authentication required.")
+		return
+	}
+
+	collection, rest := path, ""
+	if i := strings.Index(path, "/"); i >= 0 {
+		collection, rest = path[:i], path[i+1:]
+	}
+
+	var handler func(http.ResponseWriter, *http.Request, string, map[string]interface{})
+	switch collection {
+	case "array":
+		handler = fa.handleArray
+	case "volume":
+		handler = fa.handleVolume
+	case "vgroup":
+		handler = fa.handleVgroup
+	case "host":
+		handler = fa.handleHost
+	case "hgroup":
+		handler = fa.handleHgroup
+	case "pgroup":
+		handler = fa.handlePgroup
+	case "pod":
+		handler = fa.handlePod
+	case "dns":
+		handler = fa.handleDNS
+	case "smtp":
+		handler = fa.handleSMTP
+	case "snmp":
+		handler = fa.handleSnmp
+	case "subnet":
+		handler = fa.handleSubnet
+	case "network":
+		handler = fa.handleNetwork
+	case "admin":
+		handler = fa.handleAdmin
+	case "cert":
+		handler = fa.handleCert
+	case "directoryservice":
+		handler = fa.handleDirsrv
+	case "alert":
+		handler = fa.handleAlert
+	case "message":
+		handler = fa.handleMessage
+	default:
+		fakeRespondError(w, http.StatusNotFound, "", "Not found.")
+		return
+	}
+	handler(w, r, rest, body)
+}
+
+func (fa *fakeFlashArray) handleAPIToken(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+	if r.Method != http.MethodPost {
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	a, ok := fa.admins[fakeString(body, "username")]
+	if !ok || a.Password != fakeString(body, "password") || a.Token == "" {
+		fakeRespondError(w, http.StatusBadRequest, "", "invalid credentials")
+		return
+	}
+	fakeRespond(w, http.StatusOK, map[string]interface{}{"api_token": a.Token})
+}
+
+func (fa *fakeFlashArray) handleSession(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodPost:
+		a := fa.fakeAdminByToken(fakeString(body, "api_token"))
+		if a == nil {
+			fakeRespondError(w, http.StatusUnauthorized, "", "Invalid API token.")
+			return
+		}
+		id := fakeRandomHex(16)
+		fa.sessions[id] = true
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: id, Path: "/api", HttpOnly: true, Secure: true})
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"username": a.Name})
+	case http.MethodDelete:
+		if c, err := r.Cookie("session"); err == nil {
+			delete(fa.sessions, c.Value)
+		}
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"username": fa.Username})
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+	}
+}
+
+func (fa *fakeFlashArray) authenticated(r *http.Request) bool {
+	c, err := r.Cookie("session")
+	if err != nil {
+		return false
+	}
+	return fa.sessions[c.Value]
+}
+
+func (fa *fakeFlashArray) handleArray(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	switch rest {
+	case "":
+	case "console_lock":
+		fa.handleArrayToggle(w, r, body, &fa.consoleLock, "console_lock", "enabled")
+		return
+	case "phonehome":
+		fa.handleArrayToggle(w, r, body, &fa.phonehome, "phonehome", "enabled")
+		return
+	case "remoteassist":
+		fa.handleRemoteAssist(w, r, body)
+		return
+	default:
+		if rest == "connection" || strings.HasPrefix(rest, "connection/") {
+			fa.handleArrayConnection(w, r, strings.TrimPrefix(strings.TrimPrefix(rest, "connection"), "/"), body)
+			return
+		}
+		fakeRespondError(w, http.StatusNotFound, "", "Not found.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if timeout, ok := fakeInt(body, "idle_timeout"); ok && timeout != 0 && (timeout < 5 || timeout > 180) {
+			fakeRespondError(w, http.StatusBadRequest, "idle_timeout", "Idle timeout must be 0 or between 5 and 180 minutes.")
+			return
+		}
+		if name, ok := body["name"]; ok {
+			fa.ArrayName = name.(string)
+		}
+		if _, ok := body["ntpserver"]; ok {
+			fa.ntpServers = fakeStrings(body, "ntpserver")
+		}
+		if _, ok := body["syslogserver"]; ok {
+			fa.syslogServers = fakeStrings(body, "syslogserver")
+		}
+		if _, ok := body["banner"]; ok {
+			fa.banner = fakeString(body, "banner")
+		}
+		if timeout, ok := fakeInt(body, "idle_timeout"); ok {
+			fa.idleTimeout = timeout
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+
+	// Like the array, the attributes requested with a parameter are returned
+	// instead of the array name and version.
+	params := fakeParams(r)
+	if params["space"] == "true" {
+		fakeRespond(w, http.StatusOK, []map[string]interface{}{fa.space()})
+		return
+	}
+	if params["action"] == "monitor" {
+		fakeRespond(w, http.StatusOK, []map[string]interface{}{fa.monitor()})
+		return
+	}
+	out := make(map[string]interface{})
+	if params["ntpserver"] == "true" {
+		out["ntpserver"] = fa.ntpServers
+	}
+	if params["syslogserver"] == "true" {
+		out["syslogserver"] = fa.syslogServers
+	}
+	if params["banner"] == "true" {
+		out["banner"] = fa.banner
+	}
+	if params["idle_timeout"] == "true" {
+		out["idle_timeout"] = fa.idleTimeout
+	}
+	if params["phonehome"] == "true" {
+		out["phonehome"] = fakeEnabled(fa.phonehome)
+	}
+	if len(out) == 0 {
+		out = map[string]interface{}{
+			"array_name": fa.ArrayName,
+			"id":         fa.ArrayID,
+			"revision":   "201912101721+8c9d6c7",
+			"version":    "5.3.2",
+		}
+	}
+	fakeRespond(w, http.StatusOK, out)
+}
+
+// fakeCapacity is the usable capacity of the fake array, 10 TiB.
+const fakeCapacity = 10 * 1024 * 1024 * 1024 * 1024
+
+// space returns the space of the array. The data of the volumes is reduced
+// 4 to 1, and the snapshots take no space.
+func (fa *fakeFlashArray) space() map[string]interface{} {
+	volumes := 0
+	for _, v := range fa.volumes {
+		if !v.Destroyed {
+			volumes += v.Size / 4
+		}
+	}
+	shared := 1024 * 1024 * 1024
+	return map[string]interface{}{
+		"hostname":          fa.ArrayName,
+		"capacity":          fakeCapacity,
+		"total":             volumes + shared,
+		"volumes":           volumes,
+		"snapshots":         0,
+		"shared_space":      shared,
+		"system":            0,
+		"data_reduction":    4.0,
+		"total_reduction":   8.0,
+		"thin_provisioning": 0.5,
+		"parity":            1.0,
+	}
+}
+
+// monitor returns the current performance of the array.
+func (fa *fakeFlashArray) monitor() map[string]interface{} {
+	return map[string]interface{}{
+		"time":                  fakeNow(),
+		"reads_per_sec":         1200,
+		"writes_per_sec":        800,
+		"input_per_sec":         52428800,
+		"output_per_sec":        78643200,
+		"usec_per_read_op":      250,
+		"usec_per_write_op":     180,
+		"san_usec_per_read_op":  30,
+		"san_usec_per_write_op": 40,
+		"queue_depth":           4,
+	}
+}
+
+// handleArrayToggle handles an array setting that is enabled with a PUT of
+// enabled, and reported as "enabled" or "disabled" under key.
+func (fa *fakeFlashArray) handleArrayToggle(w http.ResponseWriter, r *http.Request, body map[string]interface{}, v *bool, key string, field string) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if _, ok := body[field]; ok {
+			*v = fakeBool(body, field)
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, map[string]interface{}{key: fakeEnabled(*v)})
+}
+
+func (fa *fakeFlashArray) handleRemoteAssist(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		switch fakeString(body, "action") {
+		case "connect":
+			fa.remoteAssist = true
+		case "disconnect":
+			fa.remoteAssist = false
+		default:
+			fakeRespondError(w, http.StatusBadRequest, "action", "Invalid action.")
+			return
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	status := "disconnected"
+	if fa.remoteAssist {
+		status = "connected"
+	}
+	fakeRespond(w, http.StatusOK, map[string]interface{}{"name": fa.ArrayName, "status": status, "port": ""})
+}
+
+// handleArrayConnection connects to and disconnects from the remote arrays.
+func (fa *fakeFlashArray) handleArrayConnection(w http.ResponseWriter, r *http.Request, name string, body map[string]interface{}) {
+	switch {
+	case r.Method == http.MethodGet && name == "":
+		var names []string
+		for n := range fa.arrayConnections {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		out := []map[string]interface{}{}
+		for _, n := range names {
+			out = append(out, fa.arrayConnections[n].view())
+		}
+		fakeRespond(w, http.StatusOK, out)
+	case r.Method == http.MethodPost && name == "":
+		address := fakeString(body, "management_address")
+		remote, ok := fa.remoteArrays[address]
+		if !ok {
+			fakeRespondError(w, http.StatusBadRequest, address, "Could not connect to the array.")
+			return
+		}
+		if fakeString(body, "connection_key") != fakeConnectionKey {
+			fakeRespondError(w, http.StatusBadRequest, "connection_key", "Invalid connection key.")
+			return
+		}
+		if stringInSlice(remote, fa.connectedArrays) {
+			fakeRespondError(w, http.StatusBadRequest, remote, "Array is already connected.")
+			return
+		}
+		c := &fakeArrayConnection{
+			Name:               remote,
+			ManagementAddress:  address,
+			ReplicationAddress: fakeString(body, "replication_address"),
+			Type:               fakeStrings(body, "type"),
+		}
+		if c.ReplicationAddress == "" {
+			c.ReplicationAddress = address
+		}
+		if len(c.Type) == 0 {
+			c.Type = []string{"async-replication"}
+		}
+		for _, t := range c.Type {
+			if t != "async-replication" && t != "sync-replication" {
+				fakeRespondError(w, http.StatusBadRequest, "type", "Invalid connection type.")
+				return
+			}
+		}
+		fa.arrayConnections[remote] = c
+		fa.connectedArrays = append(fa.connectedArrays, remote)
+		fakeRespond(w, http.StatusOK, c.view())
+	case r.Method == http.MethodDelete && name != "":
+		c, ok := fa.arrayConnections[name]
+		if !ok {
+			fakeRespondError(w, http.StatusBadRequest, name, "Array is not connected.")
+			return
+		}
+		for _, pg := range fa.pgroups {
+			if pg.Source == fa.ArrayName && fakeTargetIndex(pg.Targets, name) >= 0 {
+				fakeRespondError(w, http.StatusBadRequest, name, "Array is a target of a protection group.")
+				return
+			}
+		}
+		for _, pod := range fa.pods {
+			if stringInSlice(name, pod.Arrays) {
+				fakeRespondError(w, http.StatusBadRequest, name, "Array is a member of a pod.")
+				return
+			}
+		}
+		delete(fa.arrayConnections, name)
+		fa.connectedArrays = fakeRemoveString(fa.connectedArrays, name)
+		fakeRespond(w, http.StatusOK, c.view())
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+	}
+}
+
+func fakeEnabled(b bool) string {
+	if b {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func (fa *fakeFlashArray) handleDNS(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if _, ok := body["domain"]; ok {
+			fa.dns["domain"] = fakeString(body, "domain")
+		}
+		if _, ok := body["nameservers"]; ok {
+			fa.dns["nameservers"] = fakeStrings(body, "nameservers")
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, fa.dns)
+}
+
+func (fa *fakeFlashArray) handleSMTP(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		for _, key := range []string{"relay_host", "sender_domain", "user_name", "password"} {
+			if _, ok := body[key]; ok {
+				fa.smtp[key] = fakeString(body, key)
+			}
+		}
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, map[string]interface{}{
+		"relay_host":    fakeOptional(fa.smtp["relay_host"].(string)),
+		"sender_domain": fakeOptional(fa.smtp["sender_domain"].(string)),
+		"user_name":     fakeOptional(fa.smtp["user_name"].(string)),
+		"password":      fakeMasked(fa.smtp["password"].(string)),
+	})
+}
+
+func (fa *fakeFlashArray) handleAlert(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	if rest == "" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		var out []map[string]interface{}
+		for _, name := range fakeSortedKeys(fa.alerts) {
+			out = append(out, map[string]interface{}{"name": name, "enabled": fa.alerts[name]})
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	enabled, exists := fa.alerts[rest]
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if exists {
+			fakeRespondError(w, http.StatusBadRequest, rest, "Alert recipient already exists.")
+			return
+		}
+		enabled, exists = true, true
+	case http.MethodPut:
+		if v, ok := body["enabled"]; ok && exists {
+			enabled = v.(bool)
+		}
+	case http.MethodDelete:
+		if exists {
+			delete(fa.alerts, rest)
+			fakeRespond(w, http.StatusOK, map[string]interface{}{"name": rest})
+			return
+		}
+	}
+	if !exists {
+		fakeRespondError(w, http.StatusBadRequest, rest, "Alert recipient does not exist.")
+		return
+	}
+	fa.alerts[rest] = enabled
+	fakeRespond(w, http.StatusOK, map[string]interface{}{"name": rest, "enabled": enabled})
+}
+
+func (fa *fakeFlashArray) handleMessage(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	if rest == "" {
+		fakeRespond(w, http.StatusOK, fa.messages)
+		return
+	}
+	for _, m := range fa.messages {
+		if fmt.Sprintf("%v", m["id"]) == rest {
+			if v, ok := body["flagged"]; ok {
+				m["flagged"] = v
+			}
+			fakeRespond(w, http.StatusOK, m)
+			return
+		}
+	}
+	fakeRespondError(w, http.StatusBadRequest, rest, "Message does not exist.")
+}
+
+// nextSerial returns a new unique serial number in the format used by the array.
+func (fa *fakeFlashArray) nextSerial() string {
+	fa.serial++
+	return fmt.Sprintf("F4252922ADE248CF%08X", fa.serial)
+}
+
+func fakeRespond(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func fakeRespondError(w http.ResponseWriter, status int, ctx string, msg string) {
+	fakeRespond(w, status, []fakeError{{Ctx: ctx, Msg: msg}})
+}
+
+func fakeRandomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func fakeNow() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05Z")
+}
+
+func fakeString(body map[string]interface{}, key string) string {
+	if v, ok := body[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func fakeInt(body map[string]interface{}, key string) (int, bool) {
+	switch v := body[key].(type) {
+	case float64:
+		return int(v), true
+	case string:
+		var i int
+		if _, err := fmt.Sscan(v, &i); err == nil {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func fakeBool(body map[string]interface{}, key string) bool {
+	switch v := body[key].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+func fakeStrings(body map[string]interface{}, key string) []string {
+	out := []string{}
+	if l, ok := body[key].([]interface{}); ok {
+		for _, v := range l {
+			out = append(out, fmt.Sprintf("%v", v))
+		}
+	}
+	return out
+}
+
+func fakeSortedKeys(m interface{}) []string {
+	var keys []string
+	switch v := m.(type) {
+	case map[string]bool:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]int:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fakeNullable returns nil for an empty list, like the array does.
+func fakeNullable(l []string) interface{} {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+func fakeRemoveString(l []string, s string) []string {
+	var out []string
+	for _, e := range l {
+		if e != s {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func fakeReplaceString(l []string, old string, new string) []string {
+	for i, e := range l {
+		if e == old {
+			l[i] = new
+		}
+	}
+	return l
+}
@@ -0,0 +1,732 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// fakeEradicationSeconds is the time_remaining reported for destroyed objects.
+const fakeEradicationSeconds = 86400
+
+type fakeVolume struct {
+	Name      string
+	Source    string
+	Serial    string
+	Size      int
+	Created   string
+	Destroyed bool
+	fakeQos
+}
+
+// fakeQos are the QoS limits of a volume or volume group. A limit of 0 is
+// not set.
+type fakeQos struct {
+	BandwidthLimit int
+	IopsLimit      int
+}
+
+func (q *fakeQos) view(name string) map[string]interface{} {
+	out := map[string]interface{}{"name": name, "bandwidth_limit": nil, "iops_limit": nil}
+	if q.BandwidthLimit != 0 {
+		out["bandwidth_limit"] = q.BandwidthLimit
+	}
+	if q.IopsLimit != 0 {
+		out["iops_limit"] = q.IopsLimit
+	}
+	return out
+}
+
+// set applies the limits in body. An empty string removes a limit.
+func (q *fakeQos) set(w http.ResponseWriter, name string, body map[string]interface{}) bool {
+	limits := map[string]struct {
+		limit    *int
+		min, max int
+	}{
+		"bandwidth_limit": {&q.BandwidthLimit, 1024 * 1024, 512 * 1024 * 1024 * 1024},
+		"iops_limit":      {&q.IopsLimit, 100, 100000000},
+	}
+	values := make(map[string]int)
+	for k, l := range limits {
+		if _, ok := body[k]; !ok {
+			continue
+		}
+		if body[k] == "" {
+			values[k] = 0
+			continue
+		}
+		v, ok := fakeInt(body, k)
+		if !ok || v < l.min || v > l.max {
+			fakeRespondError(w, http.StatusBadRequest, name, fmt.Sprintf("Invalid %s.", k))
+			return false
+		}
+		values[k] = v
+	}
+	for k, v := range values {
+		*limits[k].limit = v
+	}
+	return true
+}
+
+func (v *fakeVolume) view(params map[string]string) map[string]interface{} {
+	out := map[string]interface{}{
+		"name":    v.Name,
+		"size":    v.Size,
+		"source":  nil,
+		"serial":  v.Serial,
+		"created": v.Created,
+	}
+	if v.Source != "" {
+		out["source"] = v.Source
+	}
+	if v.Destroyed {
+		out["time_remaining"] = fakeEradicationSeconds
+	}
+	if params["space"] == "true" {
+		out["volumes"] = v.Size / 10
+		out["snapshots"] = 0
+		out["shared_space"] = nil
+		out["system"] = nil
+		out["total"] = v.Size / 10
+		out["data_reduction"] = 5.0
+		out["total_reduction"] = 10.0
+		out["thin_provisioning"] = 0.5
+	}
+	return out
+}
+
+type fakeVgroup struct {
+	Name      string
+	Destroyed bool
+	fakeQos
+}
+
+func fakeParams(r *http.Request) map[string]string {
+	params := make(map[string]string)
+	for k, v := range r.URL.Query() {
+		params[k] = v[0]
+	}
+	return params
+}
+
+// fakePendingVisible reports whether an object with the given destroyed state
+// is visible with the pending and pending_only query parameters.
+func fakePendingVisible(destroyed bool, params map[string]string) bool {
+	if params["pending_only"] == "true" {
+		return destroyed
+	}
+	if params["pending"] == "true" {
+		return true
+	}
+	return !destroyed
+}
+
+// findVolume returns a volume or snapshot by name.
+func (fa *fakeFlashArray) findVolume(name string) *fakeVolume {
+	if v, ok := fa.volumes[name]; ok {
+		return v
+	}
+	return fa.snapshots[name]
+}
+
+func (fa *fakeFlashArray) handleVolume(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	params := fakeParams(r)
+	if rest == "" {
+		switch r.Method {
+		case http.MethodGet:
+			fa.listVolumes(w, params)
+		case http.MethodPost:
+			if fakeBool(body, "snap") {
+				fa.createSnapshots(w, fakeStrings(body, "source"), fakeString(body, "suffix"))
+				return
+			}
+			fakeRespondError(w, http.StatusBadRequest, "", "Invalid request.")
+		default:
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		}
+		return
+	}
+
+	if i := strings.LastIndex(rest, "/pgroup/"); i >= 0 {
+		fa.handleVolumePgroup(w, r, rest[:i], rest[i+len("/pgroup/"):])
+		return
+	}
+	for _, suffix := range []string{"/host", "/hgroup"} {
+		if strings.HasSuffix(rest, suffix) {
+			fa.listVolumeConnections(w, strings.TrimSuffix(rest, suffix), suffix[1:])
+			return
+		}
+	}
+
+	name := rest
+	switch r.Method {
+	case http.MethodGet:
+		if params["snap"] == "true" {
+			fa.listVolumeSnapshots(w, name, params)
+			return
+		}
+		v := fa.findVolume(name)
+		if v == nil || !fakePendingVisible(v.Destroyed, params) {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume does not exist.")
+			return
+		}
+		if params["action"] == "monitor" {
+			fakeRespond(w, http.StatusOK, []map[string]interface{}{fakeVolumeMonitor(v.Name)})
+			return
+		}
+		if params["protect"] == "true" {
+			fa.listVolumePgroups(w, v.Name)
+			return
+		}
+		if params["qos"] == "true" {
+			fakeRespond(w, http.StatusOK, v.fakeQos.view(v.Name))
+			return
+		}
+		fakeRespond(w, http.StatusOK, v.view(params))
+	case http.MethodPost:
+		fa.createVolume(w, name, body)
+	case http.MethodPut:
+		fa.setVolume(w, name, params, body)
+	case http.MethodDelete:
+		v := fa.findVolume(name)
+		if v == nil {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume does not exist.")
+			return
+		}
+		if fakeBool(body, "eradicate") {
+			if !v.Destroyed {
+				fakeRespondError(w, http.StatusBadRequest, name, "Volume must be destroyed before it can be eradicated.")
+				return
+			}
+			fa.eradicateVolume(v)
+			fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+			return
+		}
+		if v.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume has been destroyed.")
+			return
+		}
+		v.Destroyed = true
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+	}
+}
+
+func fakeVolumeMonitor(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":              name,
+		"reads_per_sec":     0,
+		"writes_per_sec":    0,
+		"input_per_sec":     0,
+		"output_per_sec":    0,
+		"usec_per_read_op":  0,
+		"usec_per_write_op": 0,
+		"time":              fakeNow(),
+	}
+}
+
+func (fa *fakeFlashArray) listVolumes(w http.ResponseWriter, params map[string]string) {
+	source := fa.volumes
+	if params["snap"] == "true" {
+		source = fa.snapshots
+	}
+	var names []string
+	if params["names"] != "" {
+		names = strings.Split(params["names"], ",")
+	} else {
+		for name := range source {
+			if params["pgrouplist"] != "" && !strings.HasPrefix(name, params["pgrouplist"]+".") {
+				continue
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	out := []map[string]interface{}{}
+	for _, name := range names {
+		v, ok := source[name]
+		if !ok || !fakePendingVisible(v.Destroyed, params) {
+			continue
+		}
+		if params["action"] == "monitor" {
+			out = append(out, fakeVolumeMonitor(v.Name))
+			continue
+		}
+		out = append(out, v.view(params))
+	}
+	fakeRespond(w, http.StatusOK, out)
+}
+
+// listVolumeSnapshots lists the snapshots of a volume, including the volume
+// snapshots taken as part of protection group snapshots.
+func (fa *fakeFlashArray) listVolumeSnapshots(w http.ResponseWriter, name string, params map[string]string) {
+	if fa.findVolume(name) == nil {
+		fakeRespondError(w, http.StatusBadRequest, name, "Volume does not exist.")
+		return
+	}
+	var names []string
+	for snapName, snap := range fa.snapshots {
+		if (snap.Source == name || snapName == name) && fakePendingVisible(snap.Destroyed, params) {
+			names = append(names, snapName)
+		}
+	}
+	sort.Strings(names)
+	out := []map[string]interface{}{}
+	for _, snapName := range names {
+		out = append(out, fa.snapshots[snapName].view(params))
+	}
+	fakeRespond(w, http.StatusOK, out)
+}
+
+// fakeVolumeBase returns the name of a volume without its pod or volume group.
+func fakeVolumeBase(name string) string {
+	if i := strings.Index(name, "::"); i >= 0 {
+		name = name[i+2:]
+	}
+	return name[strings.Index(name, "/")+1:]
+}
+
+// checkContainer verifies that the pod or volume group part of a volume name exists.
+func (fa *fakeFlashArray) checkContainer(w http.ResponseWriter, name string) bool {
+	if i := strings.Index(name, "::"); i >= 0 {
+		pod, ok := fa.pods[name[:i]]
+		if !ok || pod.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name[:i], "Pod does not exist.")
+			return false
+		}
+		return true
+	}
+	if i := strings.Index(name, "/"); i >= 0 {
+		vg, ok := fa.vgroups[name[:i]]
+		if !ok || vg.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name[:i], "Volume group does not exist.")
+			return false
+		}
+	}
+	return true
+}
+
+// copySource returns the source reported for a copy of src, which is the
+// volume itself or the volume a snapshot was taken from.
+func (fa *fakeFlashArray) copySource(src *fakeVolume) string {
+	if _, ok := fa.snapshots[src.Name]; ok {
+		return src.Source
+	}
+	return src.Name
+}
+
+func (fa *fakeFlashArray) createVolume(w http.ResponseWriter, name string, body map[string]interface{}) {
+	if !fa.checkContainer(w, name) {
+		return
+	}
+	existing := fa.volumes[name]
+
+	if source, ok := body["source"]; ok {
+		src := fa.findVolume(source.(string))
+		if src == nil || src.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, source.(string), "Volume does not exist.")
+			return
+		}
+		if existing != nil {
+			if !fakeBool(body, "overwrite") || existing.Destroyed {
+				fakeRespondError(w, http.StatusBadRequest, name, "Volume already exists.")
+				return
+			}
+			existing.Size = src.Size
+			existing.Source = fa.copySource(src)
+			fakeRespond(w, http.StatusOK, existing.view(nil))
+			return
+		}
+		v := &fakeVolume{
+			Name:    name,
+			Source:  fa.copySource(src),
+			Serial:  fa.nextSerial(),
+			Size:    src.Size,
+			Created: fakeNow(),
+		}
+		fa.volumes[name] = v
+		fakeRespond(w, http.StatusOK, v.view(nil))
+		return
+	}
+
+	if existing != nil {
+		fakeRespondError(w, http.StatusBadRequest, name, "Volume already exists.")
+		return
+	}
+	size, _ := fakeInt(body, "size")
+	if size <= 0 || size%512 != 0 {
+		fakeRespondError(w, http.StatusBadRequest, name, "Invalid volume size.")
+		return
+	}
+	v := &fakeVolume{Name: name, Serial: fa.nextSerial(), Size: size, Created: fakeNow()}
+	fa.volumes[name] = v
+	fakeRespond(w, http.StatusOK, v.view(nil))
+}
+
+func (fa *fakeFlashArray) setVolume(w http.ResponseWriter, name string, params map[string]string, body map[string]interface{}) {
+	v := fa.findVolume(name)
+	if v == nil {
+		fakeRespondError(w, http.StatusBadRequest, name, "Volume does not exist.")
+		return
+	}
+	if params["action"] == "recover" || fakeString(body, "action") == "recover" {
+		if !v.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume is not destroyed.")
+			return
+		}
+		v.Destroyed = false
+		fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+		return
+	}
+	if v.Destroyed {
+		fakeRespondError(w, http.StatusBadRequest, name, "Volume has been destroyed.")
+		return
+	}
+
+	if !v.fakeQos.set(w, name, body) {
+		return
+	}
+	if size, ok := fakeInt(body, "size"); ok {
+		if size <= 0 || size%512 != 0 {
+			fakeRespondError(w, http.StatusBadRequest, name, "Invalid volume size.")
+			return
+		}
+		if size < v.Size && !fakeBool(body, "truncate") {
+			fakeRespondError(w, http.StatusBadRequest, name, "Implicit truncation not permitted.")
+			return
+		}
+		v.Size = size
+	}
+
+	newName := name
+	if n, ok := body["name"]; ok {
+		newName = n.(string)
+	}
+	if container, ok := body["container"]; ok {
+		base := fakeVolumeBase(name)
+		newName = base
+		if c := container.(string); c != "" {
+			if _, ok := fa.pods[c]; ok {
+				newName = c + "::" + base
+			} else {
+				newName = c + "/" + base
+			}
+		}
+	}
+	if newName != name {
+		if _, ok := fa.snapshots[name]; ok {
+			fakeRespondError(w, http.StatusBadRequest, name, "Snapshots cannot be renamed.")
+			return
+		}
+		if !fa.checkContainer(w, newName) {
+			return
+		}
+		if fa.findVolume(newName) != nil {
+			fakeRespondError(w, http.StatusBadRequest, newName, "Volume already exists.")
+			return
+		}
+		fa.renameVolume(v, newName)
+	}
+	fakeRespond(w, http.StatusOK, v.view(nil))
+}
+
+// renameVolume renames a volume and updates every reference to it.
+func (fa *fakeFlashArray) renameVolume(v *fakeVolume, name string) {
+	old := v.Name
+	delete(fa.volumes, old)
+	v.Name = name
+	fa.volumes[name] = v
+	for _, h := range fa.hosts {
+		if lun, ok := h.Volumes[old]; ok {
+			delete(h.Volumes, old)
+			h.Volumes[name] = lun
+		}
+	}
+	for _, hg := range fa.hgroups {
+		if lun, ok := hg.Volumes[old]; ok {
+			delete(hg.Volumes, old)
+			hg.Volumes[name] = lun
+		}
+	}
+	for _, pg := range fa.pgroups {
+		pg.Volumes = fakeReplaceString(pg.Volumes, old, name)
+	}
+	for snapName, snap := range fa.snapshots {
+		if strings.HasPrefix(snapName, old+".") {
+			delete(fa.snapshots, snapName)
+			snap.Name = name + strings.TrimPrefix(snapName, old)
+			fa.snapshots[snap.Name] = snap
+		}
+	}
+}
+
+// eradicateVolume removes a volume and every reference to it.
+func (fa *fakeFlashArray) eradicateVolume(v *fakeVolume) {
+	if _, ok := fa.snapshots[v.Name]; ok {
+		delete(fa.snapshots, v.Name)
+		return
+	}
+	delete(fa.volumes, v.Name)
+	for _, h := range fa.hosts {
+		delete(h.Volumes, v.Name)
+	}
+	for _, hg := range fa.hgroups {
+		delete(hg.Volumes, v.Name)
+	}
+	for _, pg := range fa.pgroups {
+		pg.Volumes = fakeRemoveString(pg.Volumes, v.Name)
+	}
+}
+
+func (fa *fakeFlashArray) createSnapshots(w http.ResponseWriter, sources []string, suffix string) {
+	out := []map[string]interface{}{}
+	for _, source := range sources {
+		v, ok := fa.volumes[source]
+		if !ok || v.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, source, "Volume does not exist.")
+			return
+		}
+	}
+	for _, source := range sources {
+		v := fa.volumes[source]
+		s := suffix
+		if s == "" {
+			fa.serial++
+			s = fmt.Sprintf("%d", fa.serial)
+		}
+		name := source + "." + s
+		if _, ok := fa.snapshots[name]; ok {
+			fakeRespondError(w, http.StatusBadRequest, name, "Snapshot already exists.")
+			return
+		}
+		snap := &fakeVolume{Name: name, Source: source, Serial: fa.nextSerial(), Size: v.Size, Created: fakeNow()}
+		fa.snapshots[name] = snap
+		out = append(out, snap.view(nil))
+	}
+	fakeRespond(w, http.StatusOK, out)
+}
+
+func (fa *fakeFlashArray) listVolumeConnections(w http.ResponseWriter, name string, kind string) {
+	if _, ok := fa.volumes[name]; !ok {
+		fakeRespondError(w, http.StatusBadRequest, name, "Volume does not exist.")
+		return
+	}
+	out := []map[string]interface{}{}
+	if kind == "host" {
+		for _, h := range fa.sortedHosts() {
+			if lun, ok := h.Volumes[name]; ok {
+				out = append(out, map[string]interface{}{"name": name, "host": h.Name, "lun": lun})
+			}
+		}
+	} else {
+		for _, hg := range fa.sortedHgroups() {
+			if lun, ok := hg.Volumes[name]; ok {
+				out = append(out, map[string]interface{}{"name": name, "hgroup": hg.Name, "lun": lun})
+			}
+		}
+	}
+	fakeRespond(w, http.StatusOK, out)
+}
+
+// listVolumePgroups lists the protection groups a volume is a member of.
+func (fa *fakeFlashArray) listVolumePgroups(w http.ResponseWriter, name string) {
+	var names []string
+	for pgName, pg := range fa.pgroups {
+		if !pg.Destroyed && stringInSlice(name, pg.Volumes) {
+			names = append(names, pgName)
+		}
+	}
+	sort.Strings(names)
+	out := []map[string]interface{}{}
+	for _, pgName := range names {
+		out = append(out, map[string]interface{}{"name": name, "protection_group": pgName})
+	}
+	fakeRespond(w, http.StatusOK, out)
+}
+
+func (fa *fakeFlashArray) handleVolumePgroup(w http.ResponseWriter, r *http.Request, name string, pgroup string) {
+	v, ok := fa.volumes[name]
+	if !ok || v.Destroyed {
+		fakeRespondError(w, http.StatusBadRequest, name, "Volume does not exist.")
+		return
+	}
+	pg, ok := fa.pgroups[pgroup]
+	if !ok || pg.Destroyed {
+		fakeRespondError(w, http.StatusBadRequest, pgroup, "Protection group does not exist.")
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		if len(pg.Hosts) > 0 || len(pg.Hgroups) > 0 {
+			fakeRespondError(w, http.StatusBadRequest, pgroup, "Protection group already contains hosts or host groups.")
+			return
+		}
+		if stringInSlice(name, pg.Volumes) {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume is already a member of the protection group.")
+			return
+		}
+		pg.Volumes = append(pg.Volumes, name)
+	case http.MethodDelete:
+		if !stringInSlice(name, pg.Volumes) {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume is not a member of the protection group.")
+			return
+		}
+		pg.Volumes = fakeRemoveString(pg.Volumes, name)
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name, "protection_group": pgroup})
+}
+
+func (fa *fakeFlashArray) vgroupVolumes(name string) []string {
+	volumes := []string{}
+	for vname, v := range fa.volumes {
+		if strings.HasPrefix(vname, name+"/") && !v.Destroyed {
+			volumes = append(volumes, vname)
+		}
+	}
+	sort.Strings(volumes)
+	return volumes
+}
+
+func (fa *fakeFlashArray) vgroupView(vg *fakeVgroup) map[string]interface{} {
+	out := map[string]interface{}{"name": vg.Name, "volumes": fa.vgroupVolumes(vg.Name)}
+	if vg.Destroyed {
+		out["time_remaining"] = fakeEradicationSeconds
+	}
+	return out
+}
+
+func (fa *fakeFlashArray) handleVgroup(w http.ResponseWriter, r *http.Request, rest string, body map[string]interface{}) {
+	params := fakeParams(r)
+	if rest == "" {
+		if r.Method != http.MethodGet {
+			fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+			return
+		}
+		var names []string
+		for name := range fa.vgroups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out := []map[string]interface{}{}
+		for _, name := range names {
+			if vg := fa.vgroups[name]; fakePendingVisible(vg.Destroyed, params) {
+				out = append(out, fa.vgroupView(vg))
+			}
+		}
+		fakeRespond(w, http.StatusOK, out)
+		return
+	}
+
+	name := rest
+	vg, exists := fa.vgroups[name]
+	if r.Method == http.MethodPost {
+		if exists {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume group already exists.")
+			return
+		}
+		vg = &fakeVgroup{Name: name}
+		fa.vgroups[name] = vg
+		fakeRespond(w, http.StatusOK, fa.vgroupView(vg))
+		return
+	}
+	if !exists {
+		fakeRespondError(w, http.StatusBadRequest, name, "Volume group does not exist.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if !fakePendingVisible(vg.Destroyed, params) {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume group does not exist.")
+			return
+		}
+		if params["qos"] == "true" {
+			fakeRespond(w, http.StatusOK, vg.fakeQos.view(vg.Name))
+			return
+		}
+	case http.MethodPut:
+		if fakeString(body, "action") == "recover" {
+			if !vg.Destroyed {
+				fakeRespondError(w, http.StatusBadRequest, name, "Volume group is not destroyed.")
+				return
+			}
+			vg.Destroyed = false
+			break
+		}
+		if vg.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume group has been destroyed.")
+			return
+		}
+		if !vg.fakeQos.set(w, name, body) {
+			return
+		}
+		if n, ok := body["name"]; ok && n.(string) != name {
+			newName := n.(string)
+			if _, ok := fa.vgroups[newName]; ok {
+				fakeRespondError(w, http.StatusBadRequest, newName, "Volume group already exists.")
+				return
+			}
+			delete(fa.vgroups, name)
+			vg.Name = newName
+			fa.vgroups[newName] = vg
+			var members []*fakeVolume
+			for vname, v := range fa.volumes {
+				if strings.HasPrefix(vname, name+"/") {
+					members = append(members, v)
+				}
+			}
+			for _, v := range members {
+				fa.renameVolume(v, newName+strings.TrimPrefix(v.Name, name))
+			}
+		}
+	case http.MethodDelete:
+		if fakeBool(body, "eradicate") {
+			if !vg.Destroyed {
+				fakeRespondError(w, http.StatusBadRequest, name, "Volume group must be destroyed before it can be eradicated.")
+				return
+			}
+			for vname, v := range fa.volumes {
+				if strings.HasPrefix(vname, name+"/") {
+					fa.eradicateVolume(v)
+				}
+			}
+			delete(fa.vgroups, name)
+			fakeRespond(w, http.StatusOK, map[string]interface{}{"name": name})
+			return
+		}
+		if vg.Destroyed {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume group has been destroyed.")
+			return
+		}
+		if len(fa.vgroupVolumes(name)) > 0 {
+			fakeRespondError(w, http.StatusBadRequest, name, "Volume group is not empty.")
+			return
+		}
+		vg.Destroyed = true
+	default:
+		fakeRespondError(w, http.StatusMethodNotAllowed, "", "Method not allowed.")
+		return
+	}
+	fakeRespond(w, http.StatusOK, fa.vgroupView(vg))
+}
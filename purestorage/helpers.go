@@ -16,6 +16,18 @@
 
 package purestorage
 
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
 // Return values in slice1 that are not in slice2
 func difference(slice1 []string, slice2 []string) []string {
 	var diff []string
@@ -72,3 +84,179 @@ func stringInSlice(a string, list []string) bool {
 	}
 	return false
 }
+
+// nameFilterSchema adds the name_glob and name_regex arguments shared by the
+// data sources that list array objects to the given schema.
+func nameFilterSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["name_glob"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Shell pattern the full name of the object must match, e.g. `prod-*`.",
+		ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+			if _, err := path.Match(i.(string), ""); err != nil {
+				return nil, []error{fmt.Errorf("%s is not a valid pattern: %s", k, err)}
+			}
+			return nil, nil
+		},
+	}
+	s["name_regex"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Regular expression the full name of the object must match.",
+		ValidateFunc: validation.StringIsValidRegExp,
+	}
+	return s
+}
+
+// nameFilter returns a function matching names against the name_glob and
+// name_regex arguments of a data source.
+func nameFilter(d *schema.ResourceData) (func(string) bool, error) {
+	glob := d.Get("name_glob").(string)
+	var re *regexp.Regexp
+	if r := d.Get("name_regex").(string); r != "" {
+		var err error
+		if re, err = regexp.Compile(r); err != nil {
+			return nil, err
+		}
+	}
+	return func(name string) bool {
+		if glob != "" {
+			if ok, _ := path.Match(glob, name); !ok {
+				return false
+			}
+		}
+		return re == nil || re.MatchString(name)
+	}, nil
+}
+
+// dataSourceSchemaFromResource returns a copy of a resource schema with all
+// its attributes computed, for a data source reading the same object. The
+// data source then declares its own arguments.
+func dataSourceSchemaFromResource(rs map[string]*schema.Schema) map[string]*schema.Schema {
+	ds := make(map[string]*schema.Schema, len(rs))
+	for k, v := range rs {
+		s := &schema.Schema{
+			Type:        v.Type,
+			Description: v.Description,
+			Sensitive:   v.Sensitive,
+			Computed:    true,
+		}
+		switch elem := v.Elem.(type) {
+		case *schema.Schema:
+			s.Elem = &schema.Schema{Type: elem.Type}
+		case *schema.Resource:
+			s.Elem = &schema.Resource{Schema: dataSourceSchemaFromResource(elem.Schema)}
+		}
+		ds[k] = s
+	}
+	return ds
+}
+
+// unitsRegexp matches a number with an optional unit suffix.
+var unitsRegexp = regexp.MustCompile(`^(\d+)([KMGTP]?)$`)
+
+// parseUnits parses a number with an optional K, M, G, T or P suffix, like
+// "500M" or "10K". Each unit is base times the previous one.
+func parseUnits(s string, base int) (int, error) {
+	match := unitsRegexp.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if match == nil {
+		return 0, fmt.Errorf("'%s' is not a number with an optional K, M, G, T or P suffix", s)
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, err
+	}
+	if match[2] != "" {
+		for i := 0; i <= strings.Index("KMGTP", match[2]); i++ {
+			n *= base
+		}
+	}
+	return n, nil
+}
+
+// validateUnits returns a ValidateFunc checking that a value parsed by
+// parseUnits is between min and max.
+func validateUnits(base int, min int, max int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		n, err := parseUnits(i.(string), base)
+		if err != nil {
+			return nil, []error{fmt.Errorf("%s: %s", k, err)}
+		}
+		if n < min || n > max {
+			return nil, []error{fmt.Errorf("%s must be between %d and %d, got %d", k, min, max, n)}
+		}
+		return nil, nil
+	}
+}
+
+// suppressEquivalentUnits suppresses the diff between values that parse to
+// the same number, so "500M" in the configuration matches the number of
+// bytes read from the array.
+func suppressEquivalentUnits(base int) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		o, err := parseUnits(old, base)
+		if err != nil {
+			return false
+		}
+		n, err := parseUnits(new, base)
+		return err == nil && o == n
+	}
+}
+
+// sizeRegexp matches a size with an optional unit suffix, like "500G",
+// "2T" or "1.5TiB".
+var sizeRegexp = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(?:([KMGTP])(?:I?B)?|B)?$`)
+
+// sizeUnits are the size units, in multiples of 1024.
+const sizeUnits = "KMGTP"
+
+// parseSize parses a size in bytes with an optional K, M, G, T or P suffix
+// in multiples of 1024. The suffix may be followed by B or iB.
+func parseSize(s string) (int, error) {
+	match := sizeRegexp.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if match == nil {
+		return 0, fmt.Errorf("'%s' is not a size in bytes with an optional K, M, G, T or P suffix", s)
+	}
+	n, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, err
+	}
+	if match[2] != "" {
+		for i := 0; i <= strings.Index(sizeUnits, match[2]); i++ {
+			n *= 1024
+		}
+	}
+	if n != float64(int(n)) {
+		return 0, fmt.Errorf("'%s' is not a whole number of bytes", s)
+	}
+	return int(n), nil
+}
+
+// validateSize checks that a size parses.
+func validateSize(i interface{}, k string) ([]string, []error) {
+	if _, err := parseSize(i.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// formatSize formats a size in bytes with the largest unit it is at least
+// one of, rounded to two decimals, like "1.5T".
+func formatSize(size int) string {
+	n, unit := float64(size), ""
+	for i := 0; i < len(sizeUnits) && n >= 1024; i++ {
+		n, unit = n/1024, sizeUnits[i:i+1]
+	}
+	return strconv.FormatFloat(math.Round(n*100)/100, 'f', -1, 64) + unit
+}
+
+// suppressEquivalentSizes suppresses the diff between sizes that parse to the
+// same number of bytes.
+func suppressEquivalentSizes(k, old, new string, d *schema.ResourceData) bool {
+	o, err := parseSize(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseSize(new)
+	return err == nil && o == n
+}
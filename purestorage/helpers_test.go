@@ -55,3 +55,52 @@ func Test_stringInSlice(t *testing.T) {
 		t.Fatal("Returned false")
 	}
 }
+
+func Test_parseUnits(t *testing.T) {
+	for s, want := range map[string]int{"100": 100, "10K": 10240, "500m": 500 * 1024 * 1024, "1T": 1024 * 1024 * 1024 * 1024} {
+		if n, err := parseUnits(s, 1024); err != nil || n != want {
+			t.Fatalf("parseUnits(%q) = %d, %v, want %d", s, n, err, want)
+		}
+	}
+	if n, _ := parseUnits("10K", 1000); n != 10000 {
+		t.Fatalf("Wrong value returned: %d", n)
+	}
+	for _, s := range []string{"", "1.5M", "10KB", "-1"} {
+		if _, err := parseUnits(s, 1024); err == nil {
+			t.Fatalf("parseUnits(%q) returned no error", s)
+		}
+	}
+}
+
+func Test_validateUnits(t *testing.T) {
+	validate := validateUnits(1024, minBandwidthLimit, maxBandwidthLimit)
+	if _, errs := validate("500M", "bandwidth_limit"); len(errs) != 0 {
+		t.Fatalf("Returned errors: %v", errs)
+	}
+	for _, s := range []string{"1K", "1T", "fast"} {
+		if _, errs := validate(s, "bandwidth_limit"); len(errs) == 0 {
+			t.Fatalf("%q returned no errors", s)
+		}
+	}
+}
+
+func Test_parseSize(t *testing.T) {
+	for s, want := range map[string]int{"1048576": 1048576, "500G": 500 << 30, "2T": 2 << 40, "1.5TiB": 3 << 39, "10 MB": 10 << 20, "512B": 512} {
+		if n, err := parseSize(s); err != nil || n != want {
+			t.Fatalf("parseSize(%q) = %d, %v, want %d", s, n, err, want)
+		}
+	}
+	for _, s := range []string{"", "G", "1.5", "0.1K", "1X"} {
+		if _, err := parseSize(s); err == nil {
+			t.Fatalf("parseSize(%q) returned no error", s)
+		}
+	}
+}
+
+func Test_formatSize(t *testing.T) {
+	for size, want := range map[int]string{512: "512", 1 << 30: "1G", 3 << 39: "1.5T", 1024000000: "976.56M"} {
+		if s := formatSize(size); s != want {
+			t.Fatalf("formatSize(%d) = %s, want %s", size, s, want)
+		}
+	}
+}
@@ -17,12 +17,19 @@
 package purestorage
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider is the terraform resource provider called by main.go
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"username": {
 				Type:        schema.TypeString,
@@ -48,6 +55,20 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("PURE_TARGET", ""),
 			},
 
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PURE_PROFILE", ""),
+				Description: "Name of the profile of the profiles file to take the target, API token, CA bundle and REST version from.",
+			},
+
+			"profiles_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PURE_PROFILES_FILE", defaultProfilesFile),
+				Description: "Path of the profiles file.",
+			},
+
 			"rest_version": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -55,17 +76,23 @@ func Provider() *schema.Provider {
 			},
 
 			"verify_https": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+				Type:       schema.TypeBool,
+				Optional:   true,
+				Default:    false,
+				Deprecated: "verify_https has no effect. Use the tls block to verify the array certificate instead.",
 			},
 
 			"ssl_cert": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+				Type:       schema.TypeBool,
+				Optional:   true,
+				Default:    false,
+				Deprecated: "ssl_cert has no effect. Use the ca_file or ca_pem argument of the tls block instead.",
 			},
 
+			"tls": tlsSchema(),
+
+			"retry": retrySchema(),
+
 			"user_agent": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -77,28 +104,100 @@ func Provider() *schema.Provider {
 				Optional: true,
 				Default:  nil,
 			},
+
+			"arrays": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Additional arrays managed by the provider. A resource or data source is managed on one of them by setting its array argument to the alias of the array.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alias": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Alias of the array, used in the array argument.",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"target": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Address of the array.",
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"api_token": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"tls": tlsSchema(),
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"purefa_flasharray": dataSourcePureFlashArray(),
+			"purefa_flasharray":       dataSourcePureFlashArray(),
+			"purefa_volume_snapshots": dataSourcePureVolumeSnapshots(),
+			"purefa_volume":           dataSourcePureVolume(),
+			"purefa_volumes":          dataSourcePureVolumes(),
+			"purefa_volumegroup":      dataSourcePureVolumegroup(),
+			"purefa_host":             dataSourcePureHost(),
+			"purefa_hosts":            dataSourcePureHosts(),
+			"purefa_hostgroup":        dataSourcePureHostgroup(),
+			"purefa_hostgroups":       dataSourcePureHostgroups(),
+			"purefa_protectiongroups": dataSourcePureProtectiongroups(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"purefa_flasharray": schema.DataSourceResourceShim(
-				"purefa_flasharray",
-				dataSourcePureFlashArray(),
-			),
-			"purefa_volume":          resourcePureVolume(),
-			"purefa_host":            resourcePureHost(),
-			"purefa_hostgroup":       resourcePureHostgroup(),
-			"purefa_protectiongroup": resourcePureProtectiongroup(),
-			"purefa_volumegroup":     resourcePureVolumegroup(),
-			// "purefa_network_interface": resourcePureNetworkInterface(),
-			"purefa_dns_settings":    resourcePureDnsSettings(),
-			"purefa_alert_recipient": resourcePureAlertRecipient(),
+			"purefa_flasharray":                   resourcePureFlashArrayShim(),
+			"purefa_array_connection":             resourcePureArrayConnection(),
+			"purefa_array_settings":               resourcePureArraySettings(),
+			"purefa_volume":                       resourcePureVolume(),
+			"purefa_host":                         resourcePureHost(),
+			"purefa_hostgroup":                    resourcePureHostgroup(),
+			"purefa_host_volume_connection":       resourcePureHostVolumeConnection(),
+			"purefa_hostgroup_member":             resourcePureHostgroupMember(),
+			"purefa_hostgroup_volume_connection":  resourcePureHostgroupVolumeConnection(),
+			"purefa_protectiongroup":              resourcePureProtectiongroup(),
+			"purefa_protectiongroup_volume":       resourcePureProtectiongroupVolume(),
+			"purefa_protectiongroup_host":         resourcePureProtectiongroupHost(),
+			"purefa_protectiongroup_hostgroup":    resourcePureProtectiongroupHostgroup(),
+			"purefa_protectiongroup_target_allow": resourcePureProtectiongroupTargetAllow(),
+			"purefa_volumegroup":                  resourcePureVolumegroup(),
+			"purefa_pod":                          resourcePurePod(),
+			"purefa_volume_snapshot":              resourcePureVolumeSnapshot(),
+			"purefa_protectiongroup_snapshot":     resourcePureProtectiongroupSnapshot(),
+			"purefa_subnet":                       resourcePureSubnet(),
+			"purefa_vlan_interface":               resourcePureVlanInterface(),
+			"purefa_network_interface":            resourcePureNetworkInterface(),
+			"purefa_admin":                        resourcePureAdmin(),
+			"purefa_api_token":                    resourcePureAPIToken(),
+			"purefa_directory_service":            resourcePureDirectoryService(),
+			"purefa_directory_service_role":       resourcePureDirectoryServiceRole(),
+			"purefa_smtp_settings":                resourcePureSMTPSettings(),
+			"purefa_snmp_manager":                 resourcePureSnmpManager(),
+			"purefa_certificate":                  resourcePureCertificate(),
+			"purefa_certificate_signing_request":  resourcePureCertificateSigningRequest(),
+			"purefa_dns_settings":                 resourcePureDnsSettings(),
+			"purefa_alert_recipient":              resourcePureAlertRecipient(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
+
+	for _, r := range p.ResourcesMap {
+		withArray(r, true)
+	}
+	for _, ds := range p.DataSourcesMap {
+		withArray(ds, false)
+	}
+	return p
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -107,5 +206,105 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		return nil, err
 	}
 
-	return c.Client()
+	configs, err := NewArrayConfigs(d, c)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := configs[""]; ok {
+		return nil, fmt.Errorf("array alias can not be empty")
+	}
+	configs[""] = c
+	pool := newClientPool(configs)
+
+	// The client of the provider target is created right away, so that a
+	// wrong target or credentials fail the provider configuration. The
+	// clients of the arrays block are created when they are first used.
+	if c.Target != "" {
+		if _, err := pool.client(""); err != nil {
+			return nil, err
+		}
+	}
+	return pool, nil
+}
+
+// resourcePureFlashArrayShim is the former purefa_flasharray resource, a
+// read-only shim of the data source. It is kept for a release, so that the
+// states that still have it can be loaded.
+func resourcePureFlashArrayShim() *schema.Resource {
+	r := schema.DataSourceResourceShim("purefa_flasharray", dataSourcePureFlashArray())
+	// The optional attributes are read from the array, so they do not plan a
+	// replacement when they are not configured.
+	for _, s := range r.Schema {
+		if s.Optional {
+			s.Computed = true
+		}
+	}
+	// The shim only sets the Create and Delete functions without a context,
+	// and the data source only has a ReadContext.
+	r.Create, r.Delete = nil, nil
+	r.CreateContext = schema.CreateContextFunc(r.ReadContext)
+	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d.SetId("")
+		return nil
+	}
+	r.DeprecationMessage = "The purefa_flasharray resource does not manage anything and will be removed in the next release. " +
+		"Use the purefa_flasharray data source to read the array, or the purefa_array_settings resource to manage its settings."
+	return r
+}
+
+// withArray adds the array argument to a resource or data source, and wraps
+// its functions so that they are called with the client of the array
+// instead of the client pool.
+func withArray(r *schema.Resource, forceNew bool) {
+	r.Schema["array"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    forceNew,
+		Description: "Alias of the array in the arrays block of the provider. Defaults to the target of the provider.",
+	}
+
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			client, err := arrayClient(d, m)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return f(ctx, d, client)
+		}
+	}
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+
+	// CustomizeDiff is not wrapped, since it runs at plan time when the
+	// array may not be known or reachable yet. It is called with the client
+	// pool, and connects with arrayClient itself if it needs a client.
+
+	if r.Importer != nil && r.Importer.State != nil {
+		f := r.Importer.State
+		r.Importer.State = func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			// The array of an imported object is given as a suffix of its
+			// ID, <id>@<array>. The suffix is only split off when it is an
+			// alias of the arrays block, since IDs like the email address
+			// of an alert recipient contain "@".
+			if i := strings.LastIndex(d.Id(), "@"); i >= 0 && m.(*clientPool).hasArray(d.Id()[i+1:]) {
+				d.Set("array", d.Id()[i+1:])
+				d.SetId(d.Id()[:i])
+			}
+			client, err := arrayClient(d, m)
+			if err != nil {
+				return nil, err
+			}
+			return f(d, client)
+		}
+	}
+}
+
+// arrayClient returns the client of the array of a resource or data source.
+func arrayClient(d interface{ Get(string) interface{} }, m interface{}) (*flasharray.Client, error) {
+	return m.(*clientPool).client(d.Get("array").(string))
 }
@@ -17,11 +17,16 @@
 package purestorage
 
 import (
+	"context"
 	"os"
+	"sort"
 	"sync"
 	"testing"
 
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var testInitOnce = sync.Once{}
@@ -41,6 +46,23 @@ func init() {
 
 }
 
+// TestMain points the acceptance tests at an in-process fake FlashArray
+// when PURE_TARGET is not set, so they can run without a real array.
+func TestMain(m *testing.M) {
+	if os.Getenv("PURE_TARGET") != "" {
+		os.Exit(m.Run())
+	}
+
+	fa := newFakeFlashArray()
+	os.Setenv("PURE_TARGET", fa.Target())
+	if os.Getenv("PURE_USERNAME") == "" {
+		os.Setenv("PURE_APITOKEN", fa.APIToken)
+	}
+	code := m.Run()
+	fa.Close()
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -51,6 +73,90 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+// Manage volumes on the provider target and an array of the arrays block.
+func TestProvider_arrays(t *testing.T) {
+	fa, _ := testFakeArray(t)
+	fa2, _ := testFakeArray(t)
+	fa2.ArrayName = "fakearray2"
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"target":    fa.Target(),
+		"api_token": fa.APIToken,
+		"arrays": []interface{}{
+			map[string]interface{}{"alias": "second", "target": fa2.Target(), "api_token": fa2.APIToken},
+			map[string]interface{}{"alias": "unreachable", "target": "127.0.0.1:1", "api_token": fa2.APIToken},
+		},
+	}))
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+	pool := p.Meta()
+	r := p.ResourcesMap["purefa_volume"]
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{"name": "tfvolumetest", "size": "1M"}, pool)
+	if _, ok := fa.volumes["tfvolumetest"]; !ok || state.Attributes["array"] != "" {
+		t.Fatalf("volume was not created on the provider target: %v", state)
+	}
+	state = testFakeApply(t, r, nil, map[string]interface{}{"name": "tfvolumetest2", "size": "1M", "array": "second"}, pool)
+	if _, ok := fa2.volumes["tfvolumetest2"]; !ok || len(fa.volumes) != 1 {
+		t.Fatalf("volume was not created on the second array: %v", state)
+	}
+	testFakeImportVerify(t, r, "tfvolumetest2@second", state, pool, "allow_destroy", "eradicate_on_delete", "recover_if_destroyed")
+
+	// An ID with an "@" is only split at an alias of the arrays block.
+	alerts := p.ResourcesMap["purefa_alert_recipient"]
+	state = testFakeApply(t, alerts, nil, map[string]interface{}{"email": "ops@example.com"}, pool)
+	testFakeImportVerify(t, alerts, "ops@example.com", state, pool)
+	state = testFakeApply(t, alerts, nil, map[string]interface{}{"email": "ops@example.com", "array": "second"}, pool)
+	if !fa2.alerts["ops@example.com"] {
+		t.Fatalf("alert recipient was not created on the second array: %v", state)
+	}
+	testFakeImportVerify(t, alerts, "ops@example.com@second", state, pool)
+
+	d := testFakeRead(t, p.DataSourcesMap["purefa_flasharray"], map[string]interface{}{"array": "second"}, pool)
+	if d.Get("name") != "fakearray2" {
+		t.Fatalf("data source did not read the second array: %v", d.Get("name"))
+	}
+
+	// Unknown and unreachable arrays fail when they are used.
+	testFakeApplyError(t, r, nil, map[string]interface{}{"name": "tfvolumetest3", "size": "1M", "array": "third"}, pool)
+	testFakeApplyError(t, r, nil, map[string]interface{}{"name": "tfvolumetest3", "size": "1M", "array": "unreachable"}, pool)
+}
+
+// Plan a volume on an array that is not known yet without connecting to an
+// array.
+func TestProvider_arraysUnknown(t *testing.T) {
+	fa, _ := testFakeArray(t)
+	t.Setenv("PURE_TARGET", "")
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"arrays": []interface{}{
+			map[string]interface{}{"alias": "first", "target": fa.Target(), "api_token": fa.APIToken},
+		},
+	}))
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+	r := p.ResourcesMap["purefa_volume"]
+
+	// The unknown value of the plugin SDK.
+	const unknown = "74D93920-ED26-11E3-AC10-0800200C9A66"
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{"name": "tfvolumetest", "size": "1M", "array": unknown}), p.Meta())
+	if err != nil || diff == nil || !diff.Attributes["array"].NewComputed {
+		t.Fatalf("unexpected diff: %v %v", diff, err)
+	}
+	if _, ok := p.Meta().(*clientPool).clients["first"]; ok {
+		t.Fatalf("array was connected at plan time")
+	}
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{"name": "tfvolumetest", "size": "1M", "array": "first"}, p.Meta())
+	if _, ok := fa.volumes["tfvolumetest"]; !ok || state.Attributes["array"] != "first" {
+		t.Fatalf("volume was not created on the array: %v", state)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	target := os.Getenv("PURE_TARGET")
 	username := os.Getenv("PURE_USERNAME")
@@ -72,3 +178,160 @@ func testAccProviderMeta(t *testing.T) (interface{}, error) {
 	d := schema.TestResourceDataRaw(t, testAccProvider.Schema, make(map[string]interface{}))
 	return providerConfigure(d)
 }
+
+// testFakeArray starts a fake FlashArray for a single test and returns it
+// together with a client connected to it.
+func testFakeArray(t *testing.T) (*fakeFlashArray, *flasharray.Client) {
+	t.Helper()
+	fa := newFakeFlashArray()
+	t.Cleanup(fa.Close)
+
+	c := &Config{Target: fa.Target(), APIToken: fa.APIToken}
+	client, err := c.Client()
+	if err != nil {
+		t.Fatalf("error connecting to fake array: %s", err)
+	}
+	return fa, client
+}
+
+// testFakeApply plans and applies the raw configuration for r on top of state,
+// the way terraform apply would, and returns the new state. It fails the test
+// if a refresh and second plan afterwards are not empty.
+func testFakeApply(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, meta interface{}) *terraform.InstanceState {
+	t.Helper()
+	ctx := context.Background()
+
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		var diags diag.Diagnostics
+		if state, diags = r.Apply(ctx, state, diff, meta); diags.HasError() {
+			t.Fatalf("error applying: %v", diags)
+		}
+	}
+	if state = testFakeRefresh(t, r, state, meta); state == nil {
+		t.Fatalf("resource is gone after apply")
+	}
+
+	diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("plan is not empty after apply: %v", diff)
+	}
+	return state
+}
+
+// testFakeApplyError plans and applies the raw configuration for r on top of
+// state, and fails the test unless the plan or apply returns an error.
+func testFakeApplyError(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, meta interface{}) diag.Diagnostics {
+	t.Helper()
+	ctx := context.Background()
+
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diff == nil || diff.Empty() {
+		t.Fatalf("expected an error, but the plan is empty")
+	}
+	_, diags := r.Apply(ctx, state, diff, meta)
+	if !diags.HasError() {
+		t.Fatalf("expected an error, but apply succeeded")
+	}
+	return diags
+}
+
+// testFakeRefresh reads the resource into a new state. It returns nil if the
+// resource no longer exists.
+func testFakeRefresh(t *testing.T, r *schema.Resource, state *terraform.InstanceState, meta interface{}) *terraform.InstanceState {
+	t.Helper()
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing: %v", diags)
+	}
+	return state
+}
+
+// testFakeDestroy destroys the resource in state.
+func testFakeDestroy(t *testing.T, r *schema.Resource, state *terraform.InstanceState, meta interface{}) {
+	t.Helper()
+	if _, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, meta); diags.HasError() {
+		t.Fatalf("error destroying: %v", diags)
+	}
+}
+
+// testFakeImportVerify imports id with the importer of r and checks that the
+// imported state matches state, apart from the ignored attributes.
+func testFakeImportVerify(t *testing.T, r *schema.Resource, id string, state *terraform.InstanceState, meta interface{}, ignore ...string) {
+	t.Helper()
+	var data []*schema.ResourceData
+	var err error
+	if r.Importer.StateContext != nil {
+		data, err = r.Importer.StateContext(context.Background(), r.Data(&terraform.InstanceState{ID: id}), meta)
+	} else {
+		data, err = r.Importer.State(r.Data(&terraform.InstanceState{ID: id}), meta)
+	}
+	if err != nil {
+		t.Fatalf("error importing %s: %s", id, err)
+	}
+	if len(data) != 1 {
+		t.Fatalf("expected 1 imported resource, got %d", len(data))
+	}
+	imported := testFakeRefresh(t, r, data[0].State(), meta)
+	if imported == nil {
+		t.Fatalf("imported resource %s does not exist", id)
+	}
+
+	var keys []string
+	for k := range state.Attributes {
+		keys = append(keys, k)
+	}
+	for k := range imported.Attributes {
+		if _, ok := state.Attributes[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if stringInSlice(k, ignore) {
+			continue
+		}
+		if state.Attributes[k] != imported.Attributes[k] {
+			t.Errorf("imported attribute %s is %q, expected %q", k, imported.Attributes[k], state.Attributes[k])
+		}
+	}
+}
+
+// testFakeRead reads the data source ds with the raw configuration.
+func testFakeRead(t *testing.T, ds *schema.Resource, raw map[string]interface{}, meta interface{}) *schema.ResourceData {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, ds.Schema, raw)
+	if diags := ds.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("error reading: %v", diags)
+	}
+	return d
+}
+
+// testFakeReadError reads the data source ds with the raw configuration, and
+// returns the diagnostics of the read, which are expected to be an error.
+func testFakeReadError(t *testing.T, ds *schema.Resource, raw map[string]interface{}, meta interface{}) diag.Diagnostics {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, ds.Schema, raw)
+	diags := ds.ReadContext(context.Background(), d, meta)
+	if !diags.HasError() {
+		t.Fatalf("expected an error, but read succeeded")
+	}
+	return diags
+}
+
+// testAccClient returns the client of the provider target. It is created
+// when the provider is configured, so it is never nil in the checks of the
+// acceptance tests.
+func testAccClient() *flasharray.Client {
+	client, _ := testAccProvider.Meta().(*clientPool).client("")
+	return client
+}
@@ -0,0 +1,173 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePureAdmin() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureAdminCreate,
+		ReadContext:   resourcePureAdminRead,
+		UpdateContext: resourcePureAdminUpdate,
+		DeleteContext: resourcePureAdminDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the administrator.",
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Role of the administrator.",
+				ValidateFunc: validation.StringInSlice([]string{"readonly", "ops_admin", "storage_admin", "array_admin"}, false),
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the administrator. The password can not be read back from the array.",
+			},
+			"public_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SSH public key of the administrator.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the administrator, local or remote.",
+			},
+		},
+	}
+}
+
+func resourcePureAdminCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	// The pugo sdk does not support creating an admin with a role and password.
+	data := map[string]interface{}{
+		"role":     d.Get("role").(string),
+		"password": d.Get("password").(string),
+	}
+	if key, ok := d.GetOk("public_key"); ok {
+		data["publickey"] = key.(string)
+	}
+	req, err := client.NewRequest("POST", fmt.Sprintf("admin/%s", d.Get("name").(string)), nil, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	admin := &flasharray.User{}
+	if _, err := client.Do(req, admin, false); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(admin.Name)
+
+	return resourcePureAdminRead(ctx, d, m)
+}
+
+func resourcePureAdminRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	admin, _ := client.Users.GetAdmin(d.Id())
+	if admin == nil {
+		d.SetId("")
+		return nil
+	}
+
+	key, err := getAdminPublicKey(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", admin.Name)
+	d.Set("role", admin.Role)
+	d.Set("type", admin.Type)
+	d.Set("public_key", key.Publickey)
+	return nil
+}
+
+func resourcePureAdminUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if d.HasChange("role") {
+		if _, err := client.Users.SetAdmin(d.Id(), map[string]string{"role": d.Get("role").(string)}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// The old password is unknown after an import, in which case the
+	// password is reset instead of changed.
+	if d.HasChange("password") {
+		o, n := d.GetChange("password")
+		var err error
+		if o.(string) == "" {
+			_, err = client.Users.SetAdmin(d.Id(), map[string]string{"password": n.(string)})
+		} else {
+			_, err = client.Users.SetPassword(d.Id(), n.(string), o.(string))
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("public_key") {
+		if _, err := client.Users.SetPublicKey(d.Id(), d.Get("public_key").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePureAdminRead(ctx, d, m)
+}
+
+func resourcePureAdminDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Users.DeleteAdmin(d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// getAdminPublicKey returns the SSH public key of an admin.
+// The pugo sdk only supports listing the public keys of all admins.
+func getAdminPublicKey(client *flasharray.Client, name string) (*flasharray.PublicKey, error) {
+	params := map[string]string{"publickey": "true"}
+	req, err := client.NewRequest("GET", fmt.Sprintf("admin/%s", name), params, nil)
+	if err != nil {
+		return nil, err
+	}
+	key := &flasharray.PublicKey{}
+	if _, err := client.Do(req, key, false); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,156 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureAdminResourceName = "purefa_admin.tfadmintest"
+
+const testAdminPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEKKn6l7Gf0gXNpvS0hXbN0yWRWxY9c0S0NOvQ+ty6kL tfadmintest"
+
+// Create an admin, and change its role and password
+func TestAccResourcePureAdmin_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureAdminDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureAdminConfig(rInt, "readonly", "TfAdminTest-1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPureAdminExists(testAccCheckPureAdminResourceName, true),
+					resource.TestCheckResourceAttr(testAccCheckPureAdminResourceName, "role", "readonly"),
+					resource.TestCheckResourceAttr(testAccCheckPureAdminResourceName, "public_key", testAdminPublicKey),
+					resource.TestCheckResourceAttr(testAccCheckPureAdminResourceName, "type", "local"),
+				),
+			},
+			{
+				Config: testAccCheckPureAdminConfig(rInt, "storage_admin", "TfAdminTest-2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPureAdminExists(testAccCheckPureAdminResourceName, true),
+					resource.TestCheckResourceAttr(testAccCheckPureAdminResourceName, "role", "storage_admin"),
+				),
+			},
+			{
+				ResourceName:            testAccCheckPureAdminResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+// Create, change and delete an admin against the fake array.
+func TestResourcePureAdmin_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureAdmin()
+
+	config := map[string]interface{}{"name": "tfadmintest", "role": "readonly", "password": "TfAdminTest-1"}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "tfadmintest" || state.Attributes["type"] != "local" || state.Attributes["public_key"] != "" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	config["role"] = "storage_admin"
+	config["password"] = "TfAdminTest-2"
+	config["public_key"] = testAdminPublicKey
+	state = testFakeApply(t, r, state, config, client)
+	a := fa.admins["tfadmintest"]
+	if a.Role != "storage_admin" || a.Password != "TfAdminTest-2" || a.PublicKey != testAdminPublicKey {
+		t.Fatalf("unexpected admin after update: %#v", a)
+	}
+
+	config["public_key"] = "not a key"
+	testFakeApplyError(t, r, state, config, client)
+
+	testFakeImportVerify(t, r, state.ID, state, client, "password")
+
+	// After an import the old password is unknown and the password is reset.
+	imported := &terraform.InstanceState{ID: state.ID, Attributes: map[string]string{"name": "tfadmintest"}}
+	imported = testFakeRefresh(t, r, imported, client)
+	config["public_key"] = testAdminPublicKey
+	config["password"] = "TfAdminTest-3"
+	state = testFakeApply(t, r, imported, config, client)
+	if a.Password != "TfAdminTest-3" {
+		t.Fatalf("password was not reset: %#v", a)
+	}
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.admins["tfadmintest"]; ok {
+		t.Fatalf("admin was not deleted")
+	}
+}
+
+func testAccCheckPureAdminDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_admin" {
+			continue
+		}
+
+		_, err := client.Users.GetAdmin(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("admin '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPureAdminExists(n string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no ID is set")
+		}
+
+		client := testAccClient()
+		_, err := client.Users.GetAdmin(rs.Primary.ID)
+		if err != nil {
+			if exists {
+				return fmt.Errorf("admin does not exist: %s", n)
+			}
+			return nil
+		}
+		return nil
+	}
+}
+
+func testAccCheckPureAdminConfig(rInt int, role string, password string) string {
+	return fmt.Sprintf(`
+resource "purefa_admin" "tfadmintest" {
+	name       = "tfadmintest-%d"
+	role       = "%s"
+	password   = "%s"
+	public_key = "%s"
+}`, rInt, role, password, testAdminPublicKey)
+}
@@ -0,0 +1,126 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourcePureAPIToken manages the API token of an administrator. The token
+// is only returned by the array when it is created, so it is kept in the
+// state as it was at creation and never refreshed.
+func resourcePureAPIToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureAPITokenCreate,
+		ReadContext:   resourcePureAPITokenRead,
+		DeleteContext: resourcePureAPITokenDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"admin": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the administrator the API token belongs to.",
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Time in milliseconds the API token is valid for. The token does not expire when it is not set.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API token. It is only known when the token is created by Terraform.",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "API token creation time.",
+			},
+			"expires": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "API token expiration time.",
+			},
+		},
+	}
+}
+
+func resourcePureAPITokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	admin := d.Get("admin").(string)
+	var token *flasharray.Token
+	var err error
+	if timeout, ok := d.GetOk("timeout"); ok {
+		// The pugo sdk does not support creating an API token with a timeout.
+		var req *http.Request
+		req, err = client.NewRequest("POST", fmt.Sprintf("admin/%s/apitoken", admin), nil, map[string]int{"timeout": timeout.(int)})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		token = &flasharray.Token{}
+		_, err = client.Do(req, token, false)
+	} else {
+		token, err = client.Users.CreateAPIToken(admin)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(token.Name)
+	d.Set("token", token.APIToken)
+	return resourcePureAPITokenRead(ctx, d, m)
+}
+
+func resourcePureAPITokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	token, _ := client.Users.GetAPIToken(d.Id())
+	if token == nil || token.APIToken == "" {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("admin", token.Name)
+	d.Set("created", token.Created)
+	d.Set("expires", token.Expires)
+	return nil
+}
+
+func resourcePureAPITokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Users.DeleteAPIToken(d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
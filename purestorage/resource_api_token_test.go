@@ -0,0 +1,106 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccCheckPureAPITokenResourceName = "purefa_api_token.tfapitokentest"
+
+// Create an admin with an API token
+func TestAccResourcePureAPIToken_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureAdminDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureAPITokenConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureAPITokenResourceName, "admin", fmt.Sprintf("tfapitokentest-%d", rInt)),
+					resource.TestCheckResourceAttrSet(testAccCheckPureAPITokenResourceName, "token"),
+					resource.TestCheckResourceAttrSet(testAccCheckPureAPITokenResourceName, "created"),
+					resource.TestCheckResourceAttrSet(testAccCheckPureAPITokenResourceName, "expires"),
+				),
+			},
+		},
+	})
+}
+
+// Create an API token, log in with it and delete it against the fake array.
+func TestResourcePureAPIToken_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureAPIToken()
+	fa.admins["tfapitokentest"] = &fakeAdmin{Name: "tfapitokentest", Role: "readonly", Password: "TfAdminTest-1"}
+
+	testFakeApplyError(t, r, nil, map[string]interface{}{"admin": "tfapitokenmissing"}, client)
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{"admin": "tfapitokentest"}, client)
+	token := state.Attributes["token"]
+	if state.ID != "tfapitokentest" || token == "" || token != fa.admins["tfapitokentest"].Token || state.Attributes["expires"] != "" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	// The token is masked when it is read back, so refresh must keep it.
+	if state = testFakeRefresh(t, r, state, client); state.Attributes["token"] != token {
+		t.Fatalf("token changed after refresh: %v", state)
+	}
+
+	c := &Config{Target: fa.Target(), APIToken: token}
+	if _, err := c.Client(); err != nil {
+		t.Fatalf("error connecting with the API token: %s", err)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "token")
+
+	testFakeDestroy(t, r, state, client)
+	if fa.admins["tfapitokentest"].Token != "" {
+		t.Fatalf("API token was not deleted")
+	}
+
+	state = testFakeApply(t, r, nil, map[string]interface{}{"admin": "tfapitokentest", "timeout": 3600000}, client)
+	if state.Attributes["expires"] == "" {
+		t.Fatalf("unexpected state after create with timeout: %v", state)
+	}
+
+	// A token deleted outside of Terraform is removed from the state.
+	fa.admins["tfapitokentest"].Token = ""
+	if state = testFakeRefresh(t, r, state, client); state != nil && state.ID != "" {
+		t.Fatalf("deleted API token still in state: %v", state)
+	}
+}
+
+func testAccCheckPureAPITokenConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_admin" "tfapitokentest" {
+	name     = "tfapitokentest-%d"
+	role     = "readonly"
+	password = "TfAdminTest-1"
+}
+
+resource "purefa_api_token" "tfapitokentest" {
+	admin   = purefa_admin.tfapitokentest.name
+	timeout = 3600000
+}`, rInt)
+}
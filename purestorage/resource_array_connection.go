@@ -0,0 +1,198 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// pureArrayConnection is a connection to a remote array.
+// The pugo sdk does not support array connections.
+type pureArrayConnection struct {
+	ArrayName          string   `json:"array_name"`
+	ManagementAddress  string   `json:"management_address"`
+	ReplicationAddress string   `json:"replication_address"`
+	Type               []string `json:"type"`
+	Connected          bool     `json:"connected"`
+	Version            string   `json:"version"`
+}
+
+// arrayConnectionTypes maps the connection types to their API names. Arrays
+// running REST versions before 1.13 report async connections as
+// "replication".
+var arrayConnectionTypes = map[string]string{
+	"async": "async-replication",
+	"sync":  "sync-replication",
+}
+
+func resourcePureArrayConnection() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureArrayConnectionCreate,
+		ReadContext:   resourcePureArrayConnectionRead,
+		UpdateContext: resourcePureArrayConnectionUpdate,
+		DeleteContext: resourcePureArrayConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"management_address": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Management address of the remote array.",
+			},
+			"connection_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Connection key of the remote array. It is only used to connect the array, and is not returned by the array, so it is not imported.",
+				// A change of the key, like the key configured after an
+				// import, does not reconnect the array.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+			},
+			"replication_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Replication address of the remote array. Defaults to the management address.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "async",
+				Description:  "Type of the replication, async or sync.",
+				ValidateFunc: validation.StringInSlice([]string{"async", "sync"}, false),
+			},
+			"array_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the remote array.",
+			},
+			"connected": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the remote array is reachable.",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Purity version of the remote array.",
+			},
+		},
+	}
+}
+
+func resourcePureArrayConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	data := map[string]interface{}{
+		"management_address": d.Get("management_address").(string),
+		"connection_key":     d.Get("connection_key").(string),
+		"type":               []string{arrayConnectionTypes[d.Get("type").(string)]},
+	}
+	if v, ok := d.GetOk("replication_address"); ok {
+		data["replication_address"] = v.(string)
+	}
+
+	req, err := client.NewRequest("POST", "array/connection", nil, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	connection := &pureArrayConnection{}
+	if _, err := client.Do(req, connection, false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(connection.ArrayName)
+	return resourcePureArrayConnectionRead(ctx, d, m)
+}
+
+func resourcePureArrayConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	connection, err := getArrayConnection(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if connection == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("array_name", connection.ArrayName)
+	d.Set("management_address", connection.ManagementAddress)
+	d.Set("replication_address", connection.ReplicationAddress)
+	d.Set("connected", connection.Connected)
+	d.Set("version", connection.Version)
+	d.Set("type", "async")
+	for t, name := range arrayConnectionTypes {
+		if stringInSlice(name, connection.Type) {
+			d.Set("type", t)
+		}
+	}
+	return nil
+}
+
+// resourcePureArrayConnectionUpdate does nothing, since only the connection
+// key can change, and it is only used to connect the array.
+func resourcePureArrayConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePureArrayConnectionRead(ctx, d, m)
+}
+
+func resourcePureArrayConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	req, err := client.NewRequest("DELETE", fmt.Sprintf("array/connection/%s", d.Id()), nil, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := client.Do(req, &pureArrayConnection{}, false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// getArrayConnection returns the connection to the remote array, or nil if
+// the array is not connected.
+func getArrayConnection(client *flasharray.Client, name string) (*pureArrayConnection, error) {
+	req, err := client.NewRequest("GET", "array/connection", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var connections []pureArrayConnection
+	if _, err := client.Do(req, &connections, false); err != nil {
+		return nil, err
+	}
+	for _, c := range connections {
+		if c.ArrayName == name {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
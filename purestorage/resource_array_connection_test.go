@@ -0,0 +1,89 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Connect, import and disconnect a remote array on the fake array.
+func TestResourcePureArrayConnection_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.remoteArrays["10.0.0.2"] = "remotearray"
+	r := resourcePureArrayConnection()
+
+	config := map[string]interface{}{"management_address": "10.0.0.2", "connection_key": "invalid"}
+	testFakeApplyError(t, r, nil, config, client)
+
+	config["connection_key"] = fakeConnectionKey
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "remotearray" || state.Attributes["replication_address"] != "10.0.0.2" || state.Attributes["type"] != "async" || state.Attributes["connected"] != "true" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+	if !stringInSlice("remotearray", fa.connectedArrays) {
+		t.Fatalf("array was not connected")
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "connection_key")
+
+	// The key of an imported connection is not in its state, which must not
+	// reconnect the array.
+	imported := testFakeRefresh(t, r, &terraform.InstanceState{ID: state.ID}, client)
+	if diff, err := r.Diff(context.Background(), imported, terraform.NewResourceConfigRaw(config), client); err != nil || (diff != nil && !diff.Empty()) {
+		t.Fatalf("plan after import is not empty: %v %v", diff, err)
+	}
+
+	// An array can not be disconnected while it is a protection group target.
+	pgroup := testFakeApply(t, resourcePureProtectiongroup(), nil, map[string]interface{}{
+		"name":   "tfarrayconnectiontest",
+		"target": []interface{}{map[string]interface{}{"name": "remotearray"}},
+	}, client)
+	if _, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, client); !diags.HasError() {
+		t.Fatalf("expected an error disconnecting a protection group target")
+	}
+	testFakeDestroy(t, resourcePureProtectiongroup(), pgroup, client)
+	delete(fa.pgroups, "tfarrayconnectiontest")
+
+	testFakeDestroy(t, r, state, client)
+	if stringInSlice("remotearray", fa.connectedArrays) {
+		t.Fatalf("array is still connected")
+	}
+	if testFakeRefresh(t, r, state, client) != nil {
+		t.Fatalf("disconnected array was read")
+	}
+}
+
+// Create a sync connection with a replication address.
+func TestResourcePureArrayConnection_sync(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.remoteArrays["10.0.0.2"] = "remotearray"
+	r := resourcePureArrayConnection()
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{
+		"management_address":  "10.0.0.2",
+		"replication_address": "10.0.1.2",
+		"connection_key":      fakeConnectionKey,
+		"type":                "sync",
+	}, client)
+	if c := fa.arrayConnections["remotearray"]; c == nil || c.ReplicationAddress != "10.0.1.2" || c.Type[0] != "sync-replication" {
+		t.Fatalf("unexpected connection: %#v", c)
+	}
+	testFakeImportVerify(t, r, state.ID, state, client, "connection_key")
+}
@@ -0,0 +1,248 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourcePureArraySettings manages the settings of the array itself. Every
+// setting is optional, and a setting that is not configured is left as it is
+// on the array.
+func resourcePureArraySettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureArraySettingsCreateUpdate,
+		ReadContext:   resourcePureArraySettingsRead,
+		UpdateContext: resourcePureArraySettingsCreateUpdate,
+		DeleteContext: resourcePureArraySettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the array",
+				Optional:    true,
+				Computed:    true,
+			},
+			"ntp_servers": {
+				Type:        schema.TypeList,
+				Description: "A list of up to four NTP servers",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MaxItems:    4,
+				Optional:    true,
+				Computed:    true,
+			},
+			"syslog_servers": {
+				Type:        schema.TypeList,
+				Description: "A list of remote syslog servers, as URIs like tcp://syslog.example.com:514",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Computed:    true,
+			},
+			"banner": {
+				Type:        schema.TypeString,
+				Description: "Login banner of the array",
+				Optional:    true,
+				Computed:    true,
+			},
+			"idle_timeout": {
+				Type:        schema.TypeInt,
+				Description: "Minutes of inactivity after which a session is logged out, 0 to disable",
+				Optional:    true,
+				Computed:    true,
+				ValidateFunc: validation.Any(
+					validation.IntInSlice([]int{0}),
+					validation.IntBetween(5, 180),
+				),
+			},
+			"phonehome": {
+				Type:        schema.TypeBool,
+				Description: "Enable hourly phonehome of logs to Pure Storage support",
+				Optional:    true,
+				Computed:    true,
+			},
+			"remote_assist": {
+				Type:        schema.TypeBool,
+				Description: "Connect the remote assist session of Pure Storage support",
+				Optional:    true,
+				Computed:    true,
+			},
+			"console_lock": {
+				Type:        schema.TypeBool,
+				Description: "Lock root out of the array at the physical console",
+				Optional:    true,
+				Computed:    true,
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Description: "Purity version of the array",
+				Computed:    true,
+			},
+			"revision": {
+				Type:        schema.TypeString,
+				Description: "Purity revision of the array",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// arraySettings are the settings that are returned by GET array with a
+// parameter of the same name.
+type arraySettings struct {
+	NTPServer    []string `json:"ntpserver"`
+	SyslogServer []string `json:"syslogserver"`
+	Banner       string   `json:"banner"`
+	IdleTimeout  int      `json:"idle_timeout"`
+	Phonehome    string   `json:"phonehome"`
+}
+
+// resourcePureArraySettingsCreateUpdate changes the configured settings. When
+// the resource is created only the configured settings are changed, after
+// that only the settings that changed.
+func resourcePureArraySettingsCreateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	changed := func(k string) bool {
+		if d.IsNewResource() {
+			_, ok := d.GetOkExists(k)
+			return ok
+		}
+		return d.HasChange(k)
+	}
+
+	if changed("name") {
+		if _, err := client.Array.Rename(d.Get("name").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	data := make(map[string]interface{})
+	if changed("ntp_servers") {
+		data["ntpserver"] = d.Get("ntp_servers")
+	}
+	if changed("syslog_servers") {
+		data["syslogserver"] = d.Get("syslog_servers")
+	}
+	if changed("banner") {
+		data["banner"] = d.Get("banner").(string)
+	}
+	if changed("idle_timeout") {
+		data["idle_timeout"] = d.Get("idle_timeout").(int)
+	}
+	if len(data) > 0 {
+		if _, err := client.Array.Set(data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if changed("phonehome") {
+		var err error
+		if d.Get("phonehome").(bool) {
+			_, err = client.Array.EnablePhoneHome()
+		} else {
+			_, err = client.Array.DisablePhoneHome()
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if changed("remote_assist") {
+		var err error
+		if d.Get("remote_assist").(bool) {
+			_, err = client.Array.EnableRemoteAssist()
+		} else {
+			_, err = client.Array.DisableRemoteAssist()
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if changed("console_lock") {
+		var err error
+		if d.Get("console_lock").(bool) {
+			err = client.Array.EnableConsoleLock()
+		} else {
+			err = client.Array.DisableConsoleLock()
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePureArraySettingsRead(ctx, d, m)
+}
+
+func resourcePureArraySettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	array, err := client.Array.Get(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The pugo sdk does not support getting the ntp servers, syslog servers,
+	// banner, idle timeout and phonehome settings.
+	settings := &arraySettings{}
+	for _, param := range []string{"ntpserver", "syslogserver", "banner", "idle_timeout", "phonehome"} {
+		req, err := client.NewRequest("GET", "array", map[string]string{param: "true"}, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := client.Do(req, settings, false); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	remoteAssist, err := client.Array.GetRemoteAssist()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	consoleLock, err := client.Array.GetConsoleLock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(array.ID)
+	d.Set("name", array.ArrayName)
+	d.Set("version", array.Version)
+	d.Set("revision", array.Revision)
+	d.Set("ntp_servers", settings.NTPServer)
+	d.Set("syslog_servers", settings.SyslogServer)
+	d.Set("banner", settings.Banner)
+	d.Set("idle_timeout", settings.IdleTimeout)
+	d.Set("phonehome", settings.Phonehome == "enabled")
+	d.Set("remote_assist", remoteAssist.Status != "disconnected")
+	d.Set("console_lock", consoleLock.ConsoleLock == "enabled")
+	return nil
+}
+
+// resourcePureArraySettingsDelete only removes the settings from the state.
+// Nothing is restored on the array.
+func resourcePureArraySettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
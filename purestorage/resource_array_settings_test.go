@@ -0,0 +1,103 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccCheckPureArraySettingsResourceName = "purefa_array_settings.tfarraysettingstest"
+
+// Configure the banner and idle timeout of the array
+func TestAccResourcePureArraySettings_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureArraySettingsConfig("Authorized use only", 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureArraySettingsResourceName, "banner", "Authorized use only"),
+					resource.TestCheckResourceAttr(testAccCheckPureArraySettingsResourceName, "idle_timeout", "30"),
+					resource.TestCheckResourceAttrSet(testAccCheckPureArraySettingsResourceName, "name"),
+					resource.TestCheckResourceAttrSet(testAccCheckPureArraySettingsResourceName, "version"),
+				),
+			},
+			{
+				Config: testAccCheckPureArraySettingsConfig("Authorized use only, Terraform managed", 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureArraySettingsResourceName, "banner", "Authorized use only, Terraform managed"),
+					resource.TestCheckResourceAttr(testAccCheckPureArraySettingsResourceName, "idle_timeout", "60"),
+				),
+			},
+		},
+	})
+}
+
+// Adopt and change the settings of the fake array.
+func TestResourcePureArraySettings_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureArraySettings()
+
+	// Only the configured settings are changed when the resource is created.
+	config := map[string]interface{}{"banner": "Authorized use only", "phonehome": false}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != fa.ArrayID || state.Attributes["name"] != "fakearray" || state.Attributes["ntp_servers.0"] != "time.purestorage.com" || state.Attributes["idle_timeout"] != "30" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+	if fa.banner != "Authorized use only" || fa.phonehome {
+		t.Fatalf("settings were not changed: banner %q, phonehome %t", fa.banner, fa.phonehome)
+	}
+
+	config = map[string]interface{}{
+		"name":           "tfarraysettingstest",
+		"ntp_servers":    []interface{}{"0.pool.ntp.org", "1.pool.ntp.org"},
+		"syslog_servers": []interface{}{"tcp://syslog.testdrive.local:514"},
+		"banner":         "Authorized use only",
+		"idle_timeout":   0,
+		"phonehome":      true,
+		"remote_assist":  true,
+		"console_lock":   true,
+	}
+	state = testFakeApply(t, r, state, config, client)
+	if fa.ArrayName != "tfarraysettingstest" || len(fa.ntpServers) != 2 || len(fa.syslogServers) != 1 || fa.idleTimeout != 0 || !fa.phonehome || !fa.remoteAssist || !fa.consoleLock {
+		t.Fatalf("unexpected settings after update: %#v", fa)
+	}
+
+	config["idle_timeout"] = 3
+	testFakeApplyError(t, r, state, config, client)
+	config["idle_timeout"] = 0
+
+	testFakeImportVerify(t, r, "array-settings", state, client)
+
+	// Deleting only removes the settings from the state.
+	testFakeDestroy(t, r, state, client)
+	if fa.ArrayName != "tfarraysettingstest" || !fa.consoleLock {
+		t.Fatalf("settings were changed on delete: %#v", fa)
+	}
+}
+
+func testAccCheckPureArraySettingsConfig(banner string, idleTimeout int) string {
+	return fmt.Sprintf(`
+resource "purefa_array_settings" "tfarraysettingstest" {
+	banner       = "%s"
+	idle_timeout = %d
+}`, banner, idleTimeout)
+}
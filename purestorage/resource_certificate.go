@@ -0,0 +1,363 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// certificateSubject are the subject attributes of a certificate, which are
+// the same for a self-signed certificate and a certificate signing request.
+var certificateSubject = []string{"common_name", "country", "state", "locality", "organization", "organizational_unit", "email"}
+
+func certificateSubjectSchema(forceNew bool, computed bool) map[string]*schema.Schema {
+	descriptions := map[string]string{
+		"common_name":         "Common name of the certificate, usually the FQDN of the array",
+		"country":             "Two letter country code of the certificate",
+		"state":               "State or province of the certificate",
+		"locality":            "Locality of the certificate",
+		"organization":        "Organization of the certificate",
+		"organizational_unit": "Organizational unit of the certificate",
+		"email":               "Email address of the certificate",
+	}
+	s := make(map[string]*schema.Schema)
+	for _, k := range certificateSubject {
+		s[k] = &schema.Schema{
+			Type:        schema.TypeString,
+			Description: descriptions[k],
+			Optional:    true,
+			Computed:    computed,
+			ForceNew:    forceNew,
+		}
+	}
+	s["country"].ValidateFunc = validation.StringLenBetween(2, 2)
+	return s
+}
+
+func resourcePureCertificate() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the certificate. The management certificate of the array is named management.",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"self_signed": {
+			Type:        schema.TypeBool,
+			Description: "Generate a self-signed certificate instead of importing certificate",
+			Optional:    true,
+			Default:     false,
+		},
+		"certificate": {
+			Type:        schema.TypeString,
+			Description: "PEM encoded certificate. Required unless self_signed is set, in which case it is the generated certificate.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"private_key": {
+			Type:        schema.TypeString,
+			Description: "PEM encoded private key of the certificate. Not needed for a certificate signed from a certificate signing request of the array.",
+			Optional:    true,
+			Sensitive:   true,
+		},
+		"intermediate_certificate": {
+			Type:        schema.TypeString,
+			Description: "PEM encoded intermediate certificate chain",
+			Optional:    true,
+		},
+		"key_size": {
+			Type:        schema.TypeInt,
+			Description: "Key size in bits of a self-signed certificate",
+			Optional:    true,
+			Computed:    true,
+		},
+		"days": {
+			Type:         schema.TypeInt,
+			Description:  "Number of days a self-signed certificate is valid for",
+			Optional:     true,
+			Default:      3650,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"renew_before_days": {
+			Type:         schema.TypeInt,
+			Description:  "Number of days before it expires a self-signed certificate is generated again",
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"ready_for_renewal": {
+			Type:        schema.TypeBool,
+			Description: "Whether the certificate expires within renew_before_days",
+			Computed:    true,
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Description: "Status of the certificate",
+			Computed:    true,
+		},
+		"issued_by": {
+			Type:        schema.TypeString,
+			Description: "Issuer of the certificate",
+			Computed:    true,
+		},
+		"issued_to": {
+			Type:        schema.TypeString,
+			Description: "Subject of the certificate",
+			Computed:    true,
+		},
+		"valid_from": {
+			Type:        schema.TypeString,
+			Description: "Start of the validity of the certificate",
+			Computed:    true,
+		},
+		"valid_to": {
+			Type:        schema.TypeString,
+			Description: "Expiry of the certificate",
+			Computed:    true,
+		},
+	}
+	for k, v := range certificateSubjectSchema(false, true) {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateContext: resourcePureCertificateCreate,
+		ReadContext:   resourcePureCertificateRead,
+		UpdateContext: resourcePureCertificateUpdate,
+		DeleteContext: resourcePureCertificateDelete,
+		CustomizeDiff: resourcePureCertificateCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: s,
+	}
+}
+
+// certificateData returns the request to generate or import the certificate.
+func certificateData(d *schema.ResourceData) map[string]interface{} {
+	data := make(map[string]interface{})
+	if d.Get("self_signed").(bool) {
+		data["self_signed"] = true
+		for _, k := range certificateSubject {
+			if v, ok := d.GetOk(k); ok {
+				data[k] = v.(string)
+			}
+		}
+		if v, ok := d.GetOk("key_size"); ok {
+			data["key_size"] = v.(int)
+		}
+		data["days"] = d.Get("days").(int)
+		return data
+	}
+
+	data["certificate"] = d.Get("certificate").(string)
+	if v, ok := d.GetOk("private_key"); ok {
+		data["private_key"] = v.(string)
+	}
+	if v, ok := d.GetOk("intermediate_certificate"); ok {
+		data["intermediate_certificate"] = v.(string)
+	}
+	return data
+}
+
+// resourcePureCertificateCreate generates or imports the certificate. The
+// management certificate always exists, so it is replaced instead. Any other
+// existing certificate must be imported.
+func resourcePureCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	name := d.Get("name").(string)
+	method := "POST"
+	if existing, _ := getCertificate(client, name, nil); existing != nil {
+		if name != "management" {
+			return diag.Errorf("certificate %s already exists, import it with terraform import", name)
+		}
+		method = "PUT"
+	}
+	if _, err := setCertificate(client, method, name, certificateData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(name)
+
+	return resourcePureCertificateRead(ctx, d, m)
+}
+
+func resourcePureCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	cert, _ := getCertificate(client, d.Id(), nil)
+	if cert == nil {
+		d.SetId("")
+		return nil
+	}
+
+	pem, err := getCertificate(client, d.Id(), map[string]string{"certificate": "true"})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", cert.Name)
+	d.Set("self_signed", cert.SelfSigned)
+	// The array returns the certificate without the trailing newline of the
+	// PEM file it was imported from.
+	if strings.TrimSpace(d.Get("certificate").(string)) != strings.TrimSpace(pem.Certificate.Certificate) {
+		d.Set("certificate", pem.Certificate.Certificate)
+	}
+	d.Set("common_name", cert.CommonName)
+	d.Set("country", cert.Country)
+	d.Set("state", cert.State)
+	d.Set("locality", cert.Locality)
+	d.Set("organization", cert.Org)
+	d.Set("organizational_unit", cert.OrgUnit)
+	d.Set("email", cert.Email)
+	d.Set("key_size", cert.KeySize)
+	d.Set("status", cert.Status)
+	d.Set("issued_by", cert.IssuedBy)
+	d.Set("issued_to", cert.IssuedTo)
+	d.Set("valid_from", string(cert.ValidFrom))
+	d.Set("valid_to", string(cert.ValidTo))
+
+	renewal := false
+	if validTo, err := time.Parse(time.RFC3339, string(cert.ValidTo)); err == nil {
+		renewal = time.Now().AddDate(0, 0, d.Get("renew_before_days").(int)).After(validTo)
+	}
+	d.Set("ready_for_renewal", renewal)
+	return nil
+}
+
+func resourcePureCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	keys := []string{"self_signed", "certificate", "private_key", "intermediate_certificate"}
+	if d.Get("self_signed").(bool) {
+		keys = append([]string{"self_signed", "key_size", "days", "ready_for_renewal"}, certificateSubject...)
+	} else if d.HasChange("self_signed") && !d.HasChange("certificate") {
+		return diag.Errorf("certificate is required unless self_signed is set")
+	}
+	if d.HasChanges(keys...) {
+		if _, err := setCertificate(client, "PUT", d.Id(), certificateData(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePureCertificateRead(ctx, d, m)
+}
+
+// resourcePureCertificateDelete deletes the certificate. The management
+// certificate can not be deleted, so it is only removed from the state.
+func resourcePureCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if d.Id() != "management" {
+		if _, err := client.Cert.DeleteCert(d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourcePureCertificateCustomizeDiff checks that a certificate is
+// configured unless it is self-signed, and plans to generate a self-signed
+// certificate again when it is ready for renewal.
+func resourcePureCertificateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("self_signed") || !d.NewValueKnown("certificate") {
+		return nil
+	}
+
+	if !d.Get("self_signed").(bool) {
+		if config := d.GetRawConfig(); !config.IsNull() && config.GetAttr("certificate").IsNull() {
+			return fmt.Errorf("certificate is required unless self_signed is set")
+		}
+		return nil
+	}
+
+	keys := append([]string{"self_signed", "key_size", "days"}, certificateSubject...)
+	if d.Get("ready_for_renewal").(bool) {
+		if err := d.SetNew("ready_for_renewal", false); err != nil {
+			return err
+		}
+	} else if d.Id() == "" || !d.HasChanges(keys...) {
+		return nil
+	}
+	for _, k := range []string{"certificate", "issued_by", "issued_to", "valid_from", "valid_to"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// certificateTime is a validity date of a certificate. The array returns the
+// dates in milliseconds since the epoch, which are converted to RFC 3339.
+type certificateTime string
+
+func (t *certificateTime) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var ms int64
+	if err := json.Unmarshal(b, &ms); err == nil {
+		*t = certificateTime(time.Unix(0, ms*int64(time.Millisecond)).UTC().Format(time.RFC3339))
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*t = certificateTime(s)
+	return nil
+}
+
+type certificate struct {
+	flasharray.Certificate
+	ValidFrom certificateTime `json:"valid_from,omitempty"`
+	ValidTo   certificateTime `json:"valid_to,omitempty"`
+}
+
+// getCertificate returns the certificate with the given name.
+// The pugo sdk can not decode the validity dates of a certificate.
+func getCertificate(client *flasharray.Client, name string, params map[string]string) (*certificate, error) {
+	return doCertificate(client, "GET", name, params, nil)
+}
+
+// setCertificate creates the certificate with POST or replaces it with PUT.
+func setCertificate(client *flasharray.Client, method string, name string, data interface{}) (*certificate, error) {
+	return doCertificate(client, method, name, nil, data)
+}
+
+func doCertificate(client *flasharray.Client, method string, name string, params map[string]string, data interface{}) (*certificate, error) {
+	req, err := client.NewRequest(method, fmt.Sprintf("cert/%s", name), params, data)
+	if err != nil {
+		return nil, err
+	}
+	cert := &certificate{}
+	if _, err := client.Do(req, cert, false); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
@@ -0,0 +1,90 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourcePureCertificateSigningRequest constructs a certificate signing
+// request for the key of an existing certificate. The signed certificate is
+// imported with a purefa_certificate resource of the same name.
+func resourcePureCertificateSigningRequest() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"certificate": {
+			Type:        schema.TypeString,
+			Description: "Name of the certificate the request is constructed for",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"certificate_signing_request": {
+			Type:        schema.TypeString,
+			Description: "PEM encoded certificate signing request",
+			Computed:    true,
+		},
+	}
+	for k, v := range certificateSubjectSchema(true, false) {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateContext: resourcePureCertificateSigningRequestCreate,
+		ReadContext:   resourcePureCertificateSigningRequestRead,
+		DeleteContext: resourcePureCertificateSigningRequestDelete,
+		Schema:        s,
+	}
+}
+
+func resourcePureCertificateSigningRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	params := make(map[string]string)
+	for _, k := range certificateSubject {
+		if v, ok := d.GetOk(k); ok {
+			params[k] = v.(string)
+		}
+	}
+
+	csr, err := client.Cert.GetCSR(d.Get("certificate").(string), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(d.Get("certificate").(string))
+	d.Set("certificate_signing_request", csr.CSR)
+
+	return resourcePureCertificateSigningRequestRead(ctx, d, m)
+}
+
+// resourcePureCertificateSigningRequestRead only checks that the certificate
+// still exists. The request itself is kept as it was constructed.
+func resourcePureCertificateSigningRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if cert, _ := getCertificate(client, d.Id(), nil); cert == nil {
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourcePureCertificateSigningRequestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureCertificateResourceName = "purefa_certificate.tfcertificatetest"
+
+// Create a self-signed certificate, and change its organization
+func TestAccResourcePureCertificate_selfSigned(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureCertificateConfig(rInt, "Terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureCertificateResourceName, "self_signed", "true"),
+					resource.TestCheckResourceAttr(testAccCheckPureCertificateResourceName, "common_name", "tfcertificatetest.testdrive.local"),
+					resource.TestCheckResourceAttr(testAccCheckPureCertificateResourceName, "organization", "Terraform"),
+					resource.TestCheckResourceAttrSet(testAccCheckPureCertificateResourceName, "certificate"),
+					resource.TestCheckResourceAttrSet(testAccCheckPureCertificateResourceName, "valid_to"),
+				),
+			},
+			{
+				Config: testAccCheckPureCertificateConfig(rInt, "Terraform Test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureCertificateResourceName, "organization", "Terraform Test"),
+				),
+			},
+			{
+				ResourceName:            testAccCheckPureCertificateResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"days", "renew_before_days"},
+			},
+		},
+	})
+}
+
+// Generate, renew, import and delete a certificate against the fake array.
+func TestResourcePureCertificate_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureCertificate()
+
+	config := map[string]interface{}{
+		"name":              "tfcertificatetest",
+		"self_signed":       true,
+		"common_name":       "tfcertificatetest.testdrive.local",
+		"country":           "US",
+		"key_size":          4096,
+		"days":              365,
+		"renew_before_days": 30,
+	}
+	state := testFakeApply(t, r, nil, config, client)
+	c := fa.certs["tfcertificatetest"]
+
+	// An existing certificate other than management must be imported.
+	diags := testFakeApplyError(t, r, nil, config, client)
+	if !strings.Contains(diags[0].Summary, "already exists, import it") {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if c == nil || c.KeySize != 4096 || state.Attributes["certificate"] != c.Certificate || state.Attributes["ready_for_renewal"] != "false" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+	if validTo := c.ValidTo.Format(time.RFC3339); state.Attributes["valid_to"] != validTo {
+		t.Fatalf("expected valid_to %s, got %s", validTo, state.Attributes["valid_to"])
+	}
+
+	config["organization"] = "Terraform"
+	old := c.Certificate
+	state = testFakeApply(t, r, state, config, client)
+	if c.Org != "Terraform" || c.Certificate == old {
+		t.Fatalf("certificate was not generated again: %#v", c)
+	}
+
+	// A certificate that expires within renew_before_days is generated again.
+	c.ValidTo = time.Now().AddDate(0, 0, 10)
+	if state = testFakeRefresh(t, r, state, client); state.Attributes["ready_for_renewal"] != "true" {
+		t.Fatalf("certificate is not ready for renewal: %v", state)
+	}
+	state = testFakeApply(t, r, state, config, client)
+	if c.ValidTo.Before(time.Now().AddDate(0, 0, 300)) {
+		t.Fatalf("certificate was not renewed: %#v", c)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "days", "renew_before_days")
+
+	config = map[string]interface{}{"name": "tfcertificatetest"}
+	testFakeApplyError(t, r, state, config, client)
+
+	config["certificate"] = fakeCertPEM("CERTIFICATE") + "\n"
+	testFakeApplyError(t, r, nil, map[string]interface{}{"name": "tfcertificatetest2", "certificate": config["certificate"]}, client)
+
+	config["private_key"] = fakeCertPEM("PRIVATE KEY")
+	config["intermediate_certificate"] = fakeCertPEM("CERTIFICATE")
+	state = testFakeApply(t, r, state, config, client)
+	if c.SelfSigned || c.Certificate != config["certificate"] || state.Attributes["status"] != "imported" {
+		t.Fatalf("certificate was not imported: %#v", c)
+	}
+
+	// A changed private key is imported again with the certificate.
+	config["private_key"] = fakeCertPEM("PRIVATE KEY")
+	state = testFakeApply(t, r, state, config, client)
+	if c.PrivateKey != config["private_key"] {
+		t.Fatalf("private key was not updated: %#v", c)
+	}
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.certs["tfcertificatetest"]; ok {
+		t.Fatalf("certificate was not deleted")
+	}
+}
+
+// Sign a certificate signing request of the management certificate and
+// import the signed certificate against the fake array.
+func TestResourcePureCertificateSigningRequest_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureCertificateSigningRequest()
+
+	testFakeApplyError(t, r, nil, map[string]interface{}{"certificate": "tfcertificatemissing"}, client)
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{"certificate": "management", "common_name": "fakearray.testdrive.local", "organization": "Terraform"}, client)
+	if state.ID != "management" || state.Attributes["certificate_signing_request"] == "" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	// The signed certificate is imported without a private key, since the
+	// array already has it.
+	cert := resourcePureCertificate()
+	certState := testFakeApply(t, cert, nil, map[string]interface{}{"name": "management", "certificate": fakeCertPEM("CERTIFICATE")}, client)
+	if fa.certs["management"].SelfSigned {
+		t.Fatalf("signed certificate was not imported: %#v", fa.certs["management"])
+	}
+
+	// The management certificate is only removed from the state.
+	testFakeDestroy(t, cert, certState, client)
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.certs["management"]; !ok {
+		t.Fatalf("management certificate was deleted")
+	}
+
+	delete(fa.certs, "management")
+	if state = testFakeRefresh(t, r, &terraform.InstanceState{ID: "management", Attributes: state.Attributes}, client); state != nil && state.ID != "" {
+		t.Fatalf("request for a deleted certificate still in state: %v", state)
+	}
+}
+
+func testAccCheckPureCertificateConfig(rInt int, organization string) string {
+	return fmt.Sprintf(`
+resource "purefa_certificate" "tfcertificatetest" {
+	name         = "tfcert%d"
+	self_signed  = true
+	common_name  = "tfcertificatetest.testdrive.local"
+	country      = "US"
+	organization = "%s"
+	days         = 365
+}`, rInt, organization)
+}
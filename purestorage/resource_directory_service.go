@@ -0,0 +1,220 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePureDirectoryService() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureDirectoryServiceCreateUpdate,
+		ReadContext:   resourcePureDirectoryServiceRead,
+		UpdateContext: resourcePureDirectoryServiceCreateUpdate,
+		DeleteContext: resourcePureDirectoryServiceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"uri": {
+				Type:        schema.TypeList,
+				Description: "A list of up to 30 URIs of the directory servers, starting with ldap:// or ldaps://",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^ldaps?://`), "must start with ldap:// or ldaps://"),
+				},
+				MaxItems: 30,
+				Required: true,
+			},
+			"base_dn": {
+				Type:        schema.TypeString,
+				Description: "Base distinguished name of the directory",
+				Required:    true,
+			},
+			"bind_user": {
+				Type:        schema.TypeString,
+				Description: "User name to bind to the directory servers with",
+				Optional:    true,
+				Default:     "",
+			},
+			"bind_password": {
+				Type:        schema.TypeString,
+				Description: "Password of the bind user. The password can not be read back from the array.",
+				Optional:    true,
+				Sensitive:   true,
+				Default:     "",
+			},
+			"certificate": {
+				Type:        schema.TypeString,
+				Description: "PEM encoded CA certificate used to verify the directory servers",
+				Optional:    true,
+				Default:     "",
+			},
+			"check_peer": {
+				Type:        schema.TypeBool,
+				Description: "Verify the directory servers with the CA certificate",
+				Optional:    true,
+				Default:     false,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the directory service",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+// resourcePureDirectoryServiceCreateUpdate configures the directory service
+// and, when it is enabled, tests the connection to the directory servers
+// before enabling it. If the test does not pass, the apply fails with the
+// output of the test and the directory service is left disabled, so that a
+// broken configuration does not prevent logging in to the array.
+func resourcePureDirectoryServiceCreateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	data := map[string]interface{}{
+		"uri":        d.Get("uri"),
+		"base_dn":    d.Get("base_dn").(string),
+		"bind_user":  d.Get("bind_user").(string),
+		"check_peer": d.Get("check_peer").(bool),
+	}
+	if d.IsNewResource() || d.HasChange("bind_password") {
+		data["bind_password"] = d.Get("bind_password").(string)
+	}
+	if d.IsNewResource() || d.HasChange("certificate") {
+		data["certificate"] = d.Get("certificate").(string)
+	}
+	if _, err := client.Dirsrv.SetDirectoryService(data); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("directory-service-%s", client.Target))
+
+	if d.Get("enabled").(bool) {
+		test, err := client.Dirsrv.TestDirectoryService()
+		if err == nil {
+			err = checkDirectoryServiceTest(test.Output)
+		}
+		if err != nil {
+			if _, derr := client.Dirsrv.DisableDirectoryService(false); derr != nil {
+				return diag.Errorf("%s\nthe directory service could not be disabled: %s", err, derr)
+			}
+			return diag.FromErr(err)
+		}
+		if _, err := client.Dirsrv.EnableDirectoryService(false); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		if _, err := client.Dirsrv.DisableDirectoryService(false); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePureDirectoryServiceRead(ctx, d, m)
+}
+
+// directoryServiceTestResultRegexp matches the result lines of a directory
+// service test, such as "Connecting to ldap://ldap.example.com... PASSED".
+var directoryServiceTestResultRegexp = regexp.MustCompile(`^(.*?)[.\s]*\b(PASSED|FAILED)$`)
+
+// checkDirectoryServiceTest returns an error with the output of a directory
+// service test if one of its checks failed, or if it has no results.
+func checkDirectoryServiceTest(output string) error {
+	results := 0
+	for _, line := range strings.Split(output, "\n") {
+		match := directoryServiceTestResultRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		results++
+		if match[2] == "FAILED" {
+			return fmt.Errorf("directory service test failed:\n%s", output)
+		}
+	}
+	if results == 0 {
+		return fmt.Errorf("directory service test returned no results:\n%s", output)
+	}
+	return nil
+}
+
+func resourcePureDirectoryServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	dirsrv, err := client.Dirsrv.GetDirectoryService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The pugo sdk does not support getting the CA certificate.
+	req, err := client.NewRequest("GET", "directoryservice", map[string]string{"certificate": "true"}, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	cert := &struct {
+		Certificate string `json:"certificate"`
+	}{}
+	if _, err := client.Do(req, cert, false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("uri", dirsrv.URI)
+	d.Set("base_dn", dirsrv.BaseDn)
+	d.Set("bind_user", dirsrv.BindUser)
+	d.Set("check_peer", dirsrv.CheckPeer)
+	d.Set("enabled", dirsrv.Enabled)
+	// The array returns the certificate without the trailing newline of the
+	// PEM file it was configured with.
+	if strings.TrimSpace(d.Get("certificate").(string)) != strings.TrimSpace(cert.Certificate) {
+		d.Set("certificate", cert.Certificate)
+	}
+	return nil
+}
+
+// resourcePureDirectoryServiceDelete disables the directory service and
+// clears its configuration.
+func resourcePureDirectoryServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Dirsrv.DisableDirectoryService(false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := map[string]interface{}{
+		"uri":           []string{},
+		"base_dn":       "",
+		"bind_user":     "",
+		"bind_password": "",
+		"check_peer":    false,
+		"certificate":   "",
+	}
+	if _, err := client.Dirsrv.SetDirectoryService(data); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePureDirectoryServiceRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureDirectoryServiceRoleCreateUpdate,
+		ReadContext:   resourcePureDirectoryServiceRoleRead,
+		UpdateContext: resourcePureDirectoryServiceRoleCreateUpdate,
+		DeleteContext: resourcePureDirectoryServiceRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:         schema.TypeString,
+				Description:  "Array role the group is mapped to",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"array_admin", "ops_admin", "readonly", "storage_admin"}, false),
+			},
+			"group": {
+				Type:        schema.TypeString,
+				Description: "Common name of the LDAP group mapped to the role",
+				Required:    true,
+			},
+			"group_base": {
+				Type:        schema.TypeString,
+				Description: "Organizational units of the LDAP group, relative to the base DN of the directory service",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func resourcePureDirectoryServiceRoleCreateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	data := map[string]string{
+		"group":      d.Get("group").(string),
+		"group_base": d.Get("group_base").(string),
+	}
+	role, err := setDirectoryServiceRole(client, d.Get("role").(string), data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(role.Name)
+
+	return resourcePureDirectoryServiceRoleRead(ctx, d, m)
+}
+
+func resourcePureDirectoryServiceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	roles, err := client.Dirsrv.ListDirectoryServiceRoles()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, role := range roles {
+		if role.Name == d.Id() && role.Group != "" {
+			d.Set("role", role.Name)
+			d.Set("group", role.Group)
+			d.Set("group_base", role.GroupBase)
+			return nil
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourcePureDirectoryServiceRoleDelete removes the group mapped to the role.
+func resourcePureDirectoryServiceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := setDirectoryServiceRole(client, d.Id(), map[string]string{"group": "", "group_base": ""}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// setDirectoryServiceRole sets the group of a single role.
+// The pugo sdk does not support setting the group of a single role.
+func setDirectoryServiceRole(client *flasharray.Client, role string, data map[string]string) (*flasharray.DirsrvRole, error) {
+	req, err := client.NewRequest("PUT", fmt.Sprintf("directoryservice/role/%s", role), nil, data)
+	if err != nil {
+		return nil, err
+	}
+	r := &flasharray.DirsrvRole{}
+	if _, err := client.Do(req, r, false); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
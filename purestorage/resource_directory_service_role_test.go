@@ -0,0 +1,91 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccCheckPureDirectoryServiceRoleResourceName = "purefa_directory_service_role.tfdirectoryserviceroletest"
+
+// Map a group to the readonly role, and change the group
+func TestAccResourcePureDirectoryServiceRole_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureDirectoryServiceRoleConfig("readonly", "tfreadonly"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureDirectoryServiceRoleResourceName, "role", "readonly"),
+					resource.TestCheckResourceAttr(testAccCheckPureDirectoryServiceRoleResourceName, "group", "tfreadonly"),
+				),
+			},
+			{
+				Config: testAccCheckPureDirectoryServiceRoleConfig("readonly", "tfreadonly2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureDirectoryServiceRoleResourceName, "group", "tfreadonly2"),
+				),
+			},
+			{
+				ResourceName:      testAccCheckPureDirectoryServiceRoleResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Map, change and unmap a group against the fake array.
+func TestResourcePureDirectoryServiceRole_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureDirectoryServiceRole()
+
+	config := map[string]interface{}{"role": "array_admin", "group": "purearrayadmins", "group_base": "OU=PureGroups"}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "array_admin" || fa.dirsrv.Groups["array_admin"] != "purearrayadmins" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	config["group"] = "pureadmins"
+	state = testFakeApply(t, r, state, config, client)
+	if fa.dirsrv.Groups["array_admin"] != "pureadmins" || fa.dirsrv.GroupBases["array_admin"] != "OU=PureGroups" {
+		t.Fatalf("unexpected role after update: %v", fa.dirsrv.Groups)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	testFakeDestroy(t, r, state, client)
+	if fa.dirsrv.Groups["array_admin"] != "" || fa.dirsrv.GroupBases["array_admin"] != "" {
+		t.Fatalf("role was not unmapped: %v", fa.dirsrv.Groups)
+	}
+	if state = testFakeRefresh(t, r, state, client); state != nil && state.ID != "" {
+		t.Fatalf("unmapped role still in state: %v", state)
+	}
+}
+
+func testAccCheckPureDirectoryServiceRoleConfig(role string, group string) string {
+	return fmt.Sprintf(`
+resource "purefa_directory_service_role" "tfdirectoryserviceroletest" {
+	role       = "%s"
+	group      = "%s"
+	group_base = "OU=PureGroups,OU=SANManagers"
+}`, role, group)
+}
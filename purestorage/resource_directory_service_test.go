@@ -0,0 +1,142 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccCheckPureDirectoryServiceResourceName = "purefa_directory_service.tfdirectoryservicetest"
+
+const testDirectoryServiceCertificate = `-----BEGIN CERTIFICATE-----
+MIIBszCCAVmgAwIBAgIUTfTestCertificateForDirectoryServiceMAoGCCqGSM49BAMC
+-----END CERTIFICATE-----
+`
+
+// Configure the directory service without enabling it, since there is no
+// directory server to test against.
+func TestAccResourcePureDirectoryService_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureDirectoryServiceConfig([]string{"ldap://ldap.example.com"}, "DC=example,DC=com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureDirectoryServiceResourceName, "uri.0", "ldap://ldap.example.com"),
+					resource.TestCheckResourceAttr(testAccCheckPureDirectoryServiceResourceName, "base_dn", "DC=example,DC=com"),
+					resource.TestCheckResourceAttr(testAccCheckPureDirectoryServiceResourceName, "enabled", "false"),
+				),
+			},
+			{
+				Config: testAccCheckPureDirectoryServiceConfig([]string{"ldap://ldap1.example.com", "ldap://ldap2.example.com"}, "DC=example,DC=com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureDirectoryServiceResourceName, "uri.#", "2"),
+				),
+			},
+			{
+				ResourceName:            testAccCheckPureDirectoryServiceResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"bind_password"},
+			},
+		},
+	})
+}
+
+// Configure, test, disable and clear the directory service against the fake array.
+func TestResourcePureDirectoryService_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureDirectoryService()
+
+	config := map[string]interface{}{
+		"uri":           []interface{}{"ldap://ldap.example.com"},
+		"base_dn":       "DC=example,DC=com",
+		"bind_user":     "CN=pureuser,OU=Service,DC=example,DC=com",
+		"bind_password": "TfDirectoryServiceTest-1",
+	}
+	state := testFakeApply(t, r, nil, config, client)
+	if s := fa.dirsrv; !s.Enabled || s.BindPassword != "TfDirectoryServiceTest-1" || state.Attributes["enabled"] != "true" {
+		t.Fatalf("unexpected directory service after create: %#v", s)
+	}
+
+	// A failing test fails the apply with the test output.
+	config["uri"] = []interface{}{"ldap://ldap.example.com", "ldap://unreachable.example.com"}
+	diags := testFakeApplyError(t, r, state, config, client)
+	if !strings.Contains(diags[0].Summary, "unreachable.example.com... FAILED") {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if fa.dirsrv.Enabled {
+		t.Fatalf("directory service was left enabled after a failed test")
+	}
+
+	config["uri"] = []interface{}{"ldaps://ldap.example.com"}
+	config["certificate"] = testDirectoryServiceCertificate
+	config["check_peer"] = true
+	config["enabled"] = false
+	state = testFakeApply(t, r, state, config, client)
+	if s := fa.dirsrv; s.Enabled || !s.CheckPeer || s.Certificate != testDirectoryServiceCertificate {
+		t.Fatalf("unexpected directory service after update: %#v", s)
+	}
+
+	delete(config, "certificate")
+	testFakeApplyError(t, r, state, config, client)
+	config["certificate"] = testDirectoryServiceCertificate
+
+	testFakeImportVerify(t, r, state.ID, state, client, "bind_password")
+
+	testFakeDestroy(t, r, state, client)
+	if s := fa.dirsrv; s.Enabled || len(s.URI) != 0 || s.BaseDn != "" || s.BindPassword != "" || s.Certificate != "" {
+		t.Fatalf("directory service was not cleared: %#v", s)
+	}
+}
+
+func Test_checkDirectoryServiceTest(t *testing.T) {
+	tests := []struct {
+		output string
+		err    string
+	}{
+		{"Testing from ct0:\n    Connecting to ldap://ldap.example.com... PASSED\n    Binding as CN=pureuser... PASSED", ""},
+		{"Testing from ct0:\n    Connecting to ldap://ldap.example.com... PASSED\n    Binding as CN=pureuser... FAILED", "directory service test failed"},
+		// A FAILED in a name is not a failed check.
+		{"Testing from ct0:\n    Searching OU=FAILED,DC=example,DC=com... PASSED", ""},
+		{"Testing from ct0:", "returned no results"},
+	}
+	for _, tt := range tests {
+		err := checkDirectoryServiceTest(tt.output)
+		if tt.err == "" && err != nil {
+			t.Fatalf("unexpected error for %q: %s", tt.output, err)
+		}
+		if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Fatalf("expected error %q for %q, got %v", tt.err, tt.output, err)
+		}
+	}
+}
+
+func testAccCheckPureDirectoryServiceConfig(uri []string, baseDn string) string {
+	return fmt.Sprintf(`
+resource "purefa_directory_service" "tfdirectoryservicetest" {
+	uri       = split(";", "%s")
+	base_dn   = "%s"
+	bind_user = "CN=pureuser,OU=Service,%s"
+	enabled   = false
+}`, strings.Join(uri, ";"), baseDn, baseDn)
+}
@@ -40,7 +40,7 @@ func resourcePureHostgroup() *schema.Resource {
 				Required: true,
 			},
 			"hosts": {
-				Type: schema.TypeList,
+				Type: schema.TypeSet,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -74,7 +74,7 @@ func resourcePureHostgroupCreate(ctx context.Context, d *schema.ResourceData, m
 
 	var hosts []string
 	if h, ok := d.GetOk("hosts"); ok {
-		for _, element := range h.([]interface{}) {
+		for _, element := range h.(*schema.Set).List() {
 			hosts = append(hosts, element.(string))
 		}
 	}
@@ -119,14 +119,32 @@ func resourcePureHostgroupRead(ctx context.Context, d *schema.ResourceData, m in
 		return nil
 	}
 
+	// Only the connections of the volume block are managed by the host
+	// group, so that they can coexist with
+	// purefa_hostgroup_volume_connection resources.
 	if volumes, _ := client.Hostgroups.ListHostgroupConnections(h.Name); volumes != nil {
-		if err := d.Set("volume", flattenHgroupVolume(volumes)); err != nil {
+		managed := volumeBlockNames(d.Get("volume").(*schema.Set))
+		var connected []flasharray.HostgroupConnection
+		for _, v := range volumes {
+			if stringInSlice(v.Vol, managed) {
+				connected = append(connected, v)
+			}
+		}
+		if err := d.Set("volume", flattenHgroupVolume(connected)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
+	// Likewise only the hosts of the hosts set are managed.
+	var hosts []string
+	for _, element := range d.Get("hosts").(*schema.Set).List() {
+		if stringInSlice(element.(string), h.Hosts) {
+			hosts = append(hosts, element.(string))
+		}
+	}
+
 	d.Set("name", h.Name)
-	d.Set("hosts", h.Hosts)
+	d.Set("hosts", hosts)
 	return nil
 }
 
@@ -143,14 +161,26 @@ func resourcePureHostgroupUpdate(ctx context.Context, d *schema.ResourceData, m
 		d.Set("name", d.Get("name").(string))
 	}
 
+	// The hosts are added and removed one by one, so that the members
+	// added by purefa_hostgroup_member resources are kept.
 	if d.HasChange("hosts") {
-		var hosts []string
-		for _, element := range d.Get("hosts").([]interface{}) {
-			hosts = append(hosts, element.(string))
+		o, n := d.GetChange("hosts")
+		var oldHosts, newHosts []string
+		for _, element := range o.(*schema.Set).List() {
+			oldHosts = append(oldHosts, element.(string))
 		}
-		data := map[string][]string{"hostlist": hosts}
-		if _, err = client.Hostgroups.SetHostgroup(d.Id(), data); err != nil {
-			return diag.FromErr(err)
+		for _, element := range n.(*schema.Set).List() {
+			newHosts = append(newHosts, element.(string))
+		}
+		if remove := difference(oldHosts, newHosts); len(remove) > 0 {
+			if _, err = client.Hostgroups.SetHostgroup(d.Id(), map[string][]string{"remhostlist": remove}); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if add := difference(newHosts, oldHosts); len(add) > 0 {
+			if _, err = client.Hostgroups.SetHostgroup(d.Id(), map[string][]string{"addhostlist": add}); err != nil {
+				return diag.FromErr(err)
+			}
 		}
 	}
 
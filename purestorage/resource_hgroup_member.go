@@ -0,0 +1,98 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourcePureHostgroupMember adds a host to a host group independently of
+// the hosts list of the purefa_hostgroup resource, so that hosts managed in
+// different configurations can join a shared host group.
+func resourcePureHostgroupMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureHostgroupMemberCreate,
+		ReadContext:   resourcePureHostgroupMemberRead,
+		DeleteContext: resourcePureHostgroupMemberDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"hostgroup": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the host group.",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the host.",
+			},
+		},
+	}
+}
+
+func resourcePureHostgroupMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	hgroup := d.Get("hostgroup").(string)
+	host := d.Get("host").(string)
+	if _, err := client.Hostgroups.SetHostgroup(hgroup, map[string][]string{"addhostlist": {host}}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", hgroup, host))
+	return resourcePureHostgroupMemberRead(ctx, d, m)
+}
+
+func resourcePureHostgroupMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return diag.Errorf("invalid host group member ID '%s', expected <hostgroup>/<host>", d.Id())
+	}
+
+	host, _ := client.Hosts.GetHost(parts[1], nil)
+	if host == nil || host.Hgroup != parts[0] {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("hostgroup", host.Hgroup)
+	d.Set("host", host.Name)
+	return nil
+}
+
+func resourcePureHostgroupMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Hostgroups.SetHostgroup(d.Get("hostgroup").(string), map[string][]string{"remhostlist": {d.Get("host").(string)}}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureHostgroupMemberResourceName = "purefa_hostgroup_member.tfhostgroupmembertest"
+
+// Add a host to a host group
+func TestAccResourcePureHostgroupMember_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureHostgroupMemberDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureHostgroupMemberConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureHostgroupMemberResourceName, "hostgroup", fmt.Sprintf("tfhostgroupmembertest%d", rInt)),
+					resource.TestCheckResourceAttr(testAccCheckPureHostgroupMemberResourceName, "host", fmt.Sprintf("tfhostgroupmembertesthost%d", rInt)),
+				),
+			},
+			{
+				ResourceName:      testAccCheckPureHostgroupMemberResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Add hosts to a host group next to its hosts list on the fake array.
+func TestResourcePureHostgroupMember_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureHostgroupMember()
+
+	for _, name := range []string{"tfmemberhost1", "tfmemberhost2", "tfmemberhost3"} {
+		fa.hosts[name] = &fakeHost{Name: name, Volumes: map[string]int{}}
+	}
+	hgroup := testFakeApply(t, resourcePureHostgroup(), nil, map[string]interface{}{
+		"name":  "tfhostgroupmembertest",
+		"hosts": []interface{}{"tfmemberhost1"},
+	}, client)
+	fa.hgroups["tfotherhostgroup"] = &fakeHgroup{Name: "tfotherhostgroup", Hosts: []string{}, Volumes: map[string]int{}}
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{"hostgroup": "tfhostgroupmembertest", "host": "tfmemberhost2"}, client)
+	if state.ID != "tfhostgroupmembertest/tfmemberhost2" || fa.hosts["tfmemberhost2"].Hgroup != "tfhostgroupmembertest" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	// The host group keeps the members it does not manage.
+	hgroup = testFakeApply(t, resourcePureHostgroup(), hgroup, map[string]interface{}{
+		"name":  "tfhostgroupmembertest",
+		"hosts": []interface{}{"tfmemberhost3"},
+	}, client)
+	if hosts := fmt.Sprint(fa.hgroups["tfhostgroupmembertest"].Hosts); hosts != "[tfmemberhost2 tfmemberhost3]" {
+		t.Fatalf("unexpected host group members: %s", hosts)
+	}
+
+	// A host is a member of at most one host group.
+	testFakeApplyError(t, r, nil, map[string]interface{}{"hostgroup": "tfotherhostgroup", "host": "tfmemberhost3"}, client)
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	testFakeDestroy(t, r, state, client)
+	if fa.hosts["tfmemberhost2"].Hgroup != "" {
+		t.Fatalf("host is still a member of the host group")
+	}
+
+	fa.hosts["tfmemberhost2"].Hgroup = "tfotherhostgroup"
+	fa.hgroups["tfotherhostgroup"].Hosts = []string{"tfmemberhost2"}
+	if testFakeRefresh(t, r, state, client) != nil {
+		t.Fatalf("membership of another host group was read")
+	}
+	testFakeDestroy(t, resourcePureHostgroup(), hgroup, client)
+}
+
+func testAccCheckPureHostgroupMemberDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_hostgroup_member" {
+			continue
+		}
+
+		if host, _ := client.Hosts.GetHost(rs.Primary.Attributes["host"], nil); host != nil && host.Hgroup == rs.Primary.Attributes["hostgroup"] {
+			return fmt.Errorf("host group member '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPureHostgroupMemberConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_hostgroup" "tfhostgroupmembertest" {
+	name = "tfhostgroupmembertest%d"
+}
+
+resource "purefa_host" "tfhostgroupmembertest" {
+	name = "tfhostgroupmembertesthost%d"
+}
+
+resource "purefa_hostgroup_member" "tfhostgroupmembertest" {
+	hostgroup = purefa_hostgroup.tfhostgroupmembertest.name
+	host      = purefa_host.tfhostgroupmembertest.name
+}`, rInt, rInt)
+}
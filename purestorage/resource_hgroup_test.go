@@ -21,7 +21,6 @@ import (
 	"math/rand"
 	"testing"
 
-	"github.com/devans10/pugo/flasharray"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -145,8 +144,52 @@ func TestAccResourcePureHostgroup_update_AddandRemoveVolume(t *testing.T) {
 	})
 }
 
+// Create, update, import and delete a host group against the fake array.
+func TestResourcePureHostgroup_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureHostgroup()
+
+	for _, name := range []string{"tfhgrouphost1", "tfhgrouphost2"} {
+		if _, err := client.Hosts.CreateHost(name, nil); err != nil {
+			t.Fatalf("error creating host: %s", err)
+		}
+	}
+	if _, err := client.Volumes.CreateVolume("tfhgroupvol", 1024000000); err != nil {
+		t.Fatalf("error creating volume: %s", err)
+	}
+
+	config := map[string]interface{}{
+		"name":   "tfhgrouptest",
+		"hosts":  []interface{}{"tfhgrouphost1"},
+		"volume": []interface{}{map[string]interface{}{"vol": "tfhgroupvol", "lun": 254}},
+	}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "tfhgrouptest" || fa.hosts["tfhgrouphost1"].Hgroup != "tfhgrouptest" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	config["name"] = "tfhgrouptest-rename"
+	config["hosts"] = []interface{}{"tfhgrouphost1", "tfhgrouphost2"}
+	config["volume"] = []interface{}{}
+	state = testFakeApply(t, r, state, config, client)
+	hg := fa.hgroups["tfhgrouptest-rename"]
+	if hg == nil || len(hg.Hosts) != 2 || len(hg.Volumes) != 0 {
+		t.Fatalf("unexpected host group after update: %#v", hg)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.hgroups["tfhgrouptest-rename"]; ok {
+		t.Fatalf("host group was not deleted")
+	}
+	if fa.hosts["tfhgrouphost1"].Hgroup != "" {
+		t.Fatalf("host is still a member of the deleted host group")
+	}
+}
+
 func testAccCheckPureHostgroupDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*flasharray.Client)
+	client := testAccClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "purefa_hostgroup" {
@@ -175,7 +218,7 @@ func testAccCheckPureHostgroupExists(n string, exists bool) resource.TestCheckFu
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name := rs.Primary.Attributes["name"]
 		_, err := client.Hostgroups.GetHostgroup(name, nil)
 		if err != nil {
@@ -199,7 +242,7 @@ func testAccCheckPureHostgroupHosts(n string, host string, exists bool) resource
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name := rs.Primary.Attributes["name"]
 		h, err := client.Hostgroups.GetHostgroup(name, nil)
 		if err != nil {
@@ -230,7 +273,7 @@ func testAccCheckPureHostgroupVolumes(n string, volume string, exists bool) reso
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name := rs.Primary.Attributes["name"]
 		h, err := client.Hostgroups.ListHostgroupConnections(name)
 		if err != nil {
@@ -0,0 +1,111 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourcePureHostgroupVolumeConnection connects a volume to a host group
+// independently of the volume block of the purefa_hostgroup resource.
+func resourcePureHostgroupVolumeConnection() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureHostgroupVolumeConnectionCreate,
+		ReadContext:   resourcePureHostgroupVolumeConnectionRead,
+		DeleteContext: resourcePureHostgroupVolumeConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"hostgroup": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the host group.",
+			},
+			"volume": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Full name of the volume.",
+			},
+			"lun": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 16383),
+				Description:  "LUN of the volume. The array assigns the highest free LUN when it is not set.",
+			},
+		},
+	}
+}
+
+func resourcePureHostgroupVolumeConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	hgroup := d.Get("hostgroup").(string)
+	volume := d.Get("volume").(string)
+	data := make(map[string]interface{})
+	if lun, ok := d.GetOk("lun"); ok {
+		data["lun"] = lun.(int)
+	}
+	if _, err := client.Hostgroups.ConnectHostgroup(hgroup, volume, data); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(volumeConnectionID(hgroup, volume))
+	return resourcePureHostgroupVolumeConnectionRead(ctx, d, m)
+}
+
+func resourcePureHostgroupVolumeConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	hgroup, volume, err := parseVolumeConnectionID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volumes, _ := client.Hostgroups.ListHostgroupConnections(hgroup)
+	for _, v := range volumes {
+		if v.Vol == volume {
+			d.Set("hostgroup", hgroup)
+			d.Set("volume", volume)
+			d.Set("lun", v.Lun)
+			return nil
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePureHostgroupVolumeConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Hostgroups.DisconnectHostgroup(d.Get("hostgroup").(string), d.Get("volume").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureHostgroupVolumeConnectionResourceName = "purefa_hostgroup_volume_connection.tfhostgroupconnectiontest"
+
+// Connect a volume to a host group with a fixed LUN
+func TestAccResourcePureHostgroupVolumeConnection_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureHostgroupVolumeConnectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureHostgroupVolumeConnectionConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureHostgroupVolumeConnectionResourceName, "hostgroup", fmt.Sprintf("tfhostgroupconnectiontest%d", rInt)),
+					resource.TestCheckResourceAttr(testAccCheckPureHostgroupVolumeConnectionResourceName, "lun", "200"),
+				),
+			},
+			{
+				ResourceName:      testAccCheckPureHostgroupVolumeConnectionResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Connect volumes to a host group next to its volume block on the fake array.
+func TestResourcePureHostgroupVolumeConnection_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureHostgroupVolumeConnection()
+
+	for _, name := range []string{"tfconnvol1", "tfconnvol2"} {
+		fa.volumes[name] = &fakeVolume{Name: name, Size: 1024000000}
+	}
+	hgroup := testFakeApply(t, resourcePureHostgroup(), nil, map[string]interface{}{
+		"name":   "tfhostgroupconnectiontest",
+		"volume": []interface{}{map[string]interface{}{"vol": "tfconnvol2", "lun": 254}},
+	}, client)
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{"hostgroup": "tfhostgroupconnectiontest", "volume": "tfconnvol1"}, client)
+	if state.ID != "tfhostgroupconnectiontest/tfconnvol1" || state.Attributes["lun"] != "253" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	// The host group ignores the connections of the connection resources.
+	if refreshed := testFakeRefresh(t, resourcePureHostgroup(), hgroup, client); refreshed.Attributes["volume.#"] != "1" {
+		t.Fatalf("unexpected host group volumes: %v", refreshed)
+	}
+
+	state = testFakeApply(t, r, state, map[string]interface{}{"hostgroup": "tfhostgroupconnectiontest", "volume": "tfconnvol1", "lun": 100}, client)
+	if fa.hgroups["tfhostgroupconnectiontest"].Volumes["tfconnvol1"] != 100 {
+		t.Fatalf("unexpected connections: %v", fa.hgroups["tfhostgroupconnectiontest"].Volumes)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	testFakeDestroy(t, r, state, client)
+	if volumes := fa.hgroups["tfhostgroupconnectiontest"].Volumes; len(volumes) != 1 || volumes["tfconnvol2"] != 254 {
+		t.Fatalf("unexpected connections after destroy: %v", volumes)
+	}
+}
+
+func testAccCheckPureHostgroupVolumeConnectionDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_hostgroup_volume_connection" {
+			continue
+		}
+
+		volumes, _ := client.Hostgroups.ListHostgroupConnections(rs.Primary.Attributes["hostgroup"])
+		for _, v := range volumes {
+			if v.Vol == rs.Primary.Attributes["volume"] {
+				return fmt.Errorf("connection '%s' still exists", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPureHostgroupVolumeConnectionConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_volume" "tfhostgroupconnectiontest" {
+	name = "tfhostgroupconnectiontest-volume-%d"
+	size = 1024000000
+}
+
+resource "purefa_hostgroup" "tfhostgroupconnectiontest" {
+	name = "tfhostgroupconnectiontest%d"
+}
+
+resource "purefa_hostgroup_volume_connection" "tfhostgroupconnectiontest" {
+	hostgroup = purefa_hostgroup.tfhostgroupconnectiontest.name
+	volume    = purefa_volume.tfhostgroupconnectiontest.name
+	lun       = 200
+}`, rInt, rInt)
+}
@@ -72,6 +72,7 @@ func resourcePureHost() *schema.Resource {
 				Description: "Host password for CHAP authentication.",
 				Computed:    true,
 				Optional:    true,
+				Sensitive:   true,
 			},
 			"host_user": {
 				Type:        schema.TypeString,
@@ -96,14 +97,17 @@ func resourcePureHost() *schema.Resource {
 				Default:  nil,
 			},
 			"hgroup": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Description: "Host group the host is a member of. The membership is not managed when not set.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"target_password": {
 				Type:        schema.TypeString,
 				Description: "Target password for CHAP authentication.",
 				Computed:    true,
 				Optional:    true,
+				Sensitive:   true,
 			},
 			"target_user": {
 				Type:        schema.TypeString,
@@ -234,6 +238,12 @@ func resourcePureHostCreate(ctx context.Context, d *schema.ResourceData, m inter
 		d.Set("personality", personality.(string))
 	}
 
+	if hgroup, ok := d.GetOk("hgroup"); ok {
+		if _, err := client.Hostgroups.SetHostgroup(hgroup.(string), map[string][]string{"addhostlist": {h.Name}}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if cv := d.Get("volume").(*schema.Set).List(); len(cv) > 0 {
 		for _, volume := range cv {
 			vol, _ := volume.(map[string]interface{})
@@ -260,8 +270,17 @@ func resourcePureHostRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return nil
 	}
 
+	// Only the connections of the volume block are managed by the host, so
+	// that they can coexist with purefa_host_volume_connection resources.
 	if volumes, _ := client.Hosts.ListHostConnections(host.Name, map[string]string{"private": "true"}); volumes != nil {
-		if err := d.Set("volume", flattenVolume(volumes)); err != nil {
+		managed := volumeBlockNames(d.Get("volume").(*schema.Set))
+		var connected []flasharray.ConnectedVolume
+		for _, v := range volumes {
+			if stringInSlice(v.Vol, managed) {
+				connected = append(connected, v)
+			}
+		}
+		if err := d.Set("volume", flattenVolume(connected)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -271,6 +290,13 @@ func resourcePureHostRead(ctx context.Context, d *schema.ResourceData, m interfa
 	d.Set("wwn", host.Wwn)
 	d.Set("nqn", host.Nqn)
 
+	// The membership is only tracked when it is managed by this host, so
+	// that a host group joined through purefa_hostgroup or
+	// purefa_hostgroup_member is not left on delete.
+	if d.Get("hgroup").(string) != "" {
+		d.Set("hgroup", host.Hgroup)
+	}
+
 	host, _ = client.Hosts.GetHost(d.Id(), map[string]string{"preferred_array": "true"})
 	d.Set("preferred_array", host.PreferredArray)
 
@@ -386,6 +412,20 @@ func resourcePureHostUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		d.Set("personality", d.Get("personality").(string))
 	}
 
+	if d.HasChange("hgroup") {
+		o, n := d.GetChange("hgroup")
+		if o.(string) != "" {
+			if _, err = client.Hostgroups.SetHostgroup(o.(string), map[string][]string{"remhostlist": {d.Id()}}); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if n.(string) != "" {
+			if _, err = client.Hostgroups.SetHostgroup(n.(string), map[string][]string{"addhostlist": {d.Id()}}); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	if d.HasChange("volume") {
 		o, n := d.GetChange("volume")
 		if o == nil {
@@ -437,6 +477,14 @@ func resourcePureHostDelete(ctx context.Context, d *schema.ResourceData, m inter
 		}
 	}
 
+	// A host in a host group cannot be deleted, so the host group set on
+	// the host is left first.
+	if hgroup := d.Get("hgroup").(string); hgroup != "" {
+		if _, err := client.Hostgroups.SetHostgroup(hgroup, map[string][]string{"remhostlist": {d.Id()}}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if _, err := client.Hosts.DeleteHost(d.Id()); err != nil {
 		return diag.FromErr(err)
 	}
@@ -464,6 +512,7 @@ func resourcePureHostImport(d *schema.ResourceData, m interface{}) ([]*schema.Re
 	d.Set("iqn", host.Iqn)
 	d.Set("wwn", host.Wwn)
 	d.Set("nqn", host.Nqn)
+	d.Set("hgroup", host.Hgroup)
 
 	host, _ = client.Hosts.GetHost(d.Id(), map[string]string{"preferred_array": "true"})
 	d.Set("preferred_array", host.PreferredArray)
@@ -17,12 +17,12 @@
 package purestorage
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"strconv"
 	"testing"
 
-	"github.com/devans10/pugo/flasharray"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -312,8 +312,97 @@ func TestAccResourcePureHost_update_withPersonality(t *testing.T) {
 	})
 }
 
+// Create, update, import and delete a host against the fake array.
+func TestResourcePureHost_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureHost()
+
+	for _, name := range []string{"tfhostvol1", "tfhostvol2"} {
+		if _, err := client.Volumes.CreateVolume(name, 1024000000); err != nil {
+			t.Fatalf("error creating volume: %s", err)
+		}
+	}
+
+	config := map[string]interface{}{
+		"name":   "tfhosttest",
+		"wwn":    []interface{}{"0000999900009999"},
+		"volume": []interface{}{map[string]interface{}{"vol": "tfhostvol1", "lun": 1}},
+	}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "tfhosttest" || fa.hosts["tfhosttest"].Volumes["tfhostvol1"] != 1 {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	config["name"] = "tfhosttest-rename"
+	config["iqn"] = []interface{}{"iqn.2016-04.com.open-iscsi:tfhosttest"}
+	config["personality"] = "esxi"
+	config["volume"] = []interface{}{map[string]interface{}{"vol": "tfhostvol2", "lun": 2}}
+	state = testFakeApply(t, r, state, config, client)
+	h := fa.hosts["tfhosttest-rename"]
+	if h == nil || h.Personality != "esxi" || len(h.Iqn) != 1 || len(h.Volumes) != 1 || h.Volumes["tfhostvol2"] != 2 {
+		t.Fatalf("unexpected host after update: %#v", h)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.hosts["tfhosttest-rename"]; ok {
+		t.Fatalf("host was not deleted")
+	}
+}
+
+// Join and leave host groups through the hgroup of the host on the fake array.
+func TestResourcePureHost_hgroup(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureHost()
+
+	for _, name := range []string{"tfhostgroup1", "tfhostgroup2"} {
+		fa.hgroups[name] = &fakeHgroup{Name: name, Hosts: []string{}, Volumes: map[string]int{}}
+	}
+
+	config := map[string]interface{}{"name": "tfhosttest", "hgroup": "tfhostgroup1"}
+	state := testFakeApply(t, r, nil, config, client)
+	if fa.hosts["tfhosttest"].Hgroup != "tfhostgroup1" || state.Attributes["hgroup"] != "tfhostgroup1" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	config["hgroup"] = "tfhostgroup2"
+	state = testFakeApply(t, r, state, config, client)
+	if fa.hosts["tfhosttest"].Hgroup != "tfhostgroup2" || len(fa.hgroups["tfhostgroup1"].Hosts) != 0 {
+		t.Fatalf("unexpected host after update: %#v", fa.hosts["tfhosttest"])
+	}
+
+	// The membership is left alone when the hgroup is not set.
+	delete(config, "hgroup")
+	state = testFakeApply(t, r, state, config, client)
+	if fa.hosts["tfhosttest"].Hgroup != "tfhostgroup2" {
+		t.Fatalf("host left the host group")
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.hosts["tfhosttest"]; ok || len(fa.hgroups["tfhostgroup2"].Hosts) != 0 {
+		t.Fatalf("host was not deleted")
+	}
+
+	// A host group joined outside of the host is not left on delete.
+	state = testFakeApply(t, r, nil, map[string]interface{}{"name": "tfhosttest"}, client)
+	fa.hosts["tfhosttest"].Hgroup = "tfhostgroup1"
+	fa.hgroups["tfhostgroup1"].Hosts = []string{"tfhosttest"}
+	if state = testFakeRefresh(t, r, state, client); state.Attributes["hgroup"] != "" {
+		t.Fatalf("unmanaged host group was read: %v", state)
+	}
+	if _, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, client); !diags.HasError() {
+		t.Fatalf("expected an error deleting a member of an unmanaged host group")
+	}
+	if fa.hosts["tfhosttest"].Hgroup != "tfhostgroup1" {
+		t.Fatalf("host left the unmanaged host group")
+	}
+}
+
 func testAccCheckPureHostDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*flasharray.Client)
+	client := testAccClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "purefa_host" {
@@ -341,7 +430,7 @@ func testAccCheckPureHostExists(n string, exists bool) resource.TestCheckFunc {
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name, ok := rs.Primary.Attributes["name"]
 		_, err := client.Hosts.GetHost(name, nil)
 		if err != nil {
@@ -365,7 +454,7 @@ func testAccCheckPureHostWWN(n string, wwn string, exists bool) resource.TestChe
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name, ok := rs.Primary.Attributes["name"]
 		h, err := client.Hosts.GetHost(name, nil)
 		if err != nil {
@@ -395,7 +484,7 @@ func testAccCheckPureHostVolumeConnection(n string, volume string, exists bool)
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name, ok := rs.Primary.Attributes["name"]
 		volumes, err := client.Hosts.ListHostConnections(name, map[string]string{"private": "true"})
 		if err != nil {
@@ -427,7 +516,7 @@ func testAccCheckPureHostCHAP(n string, param string, value string, exists bool)
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name, ok := rs.Primary.Attributes["name"]
 		h, err := client.Hosts.GetHost(name, map[string]string{"chap": "true"})
 		if err != nil {
@@ -496,7 +585,7 @@ func testAccCheckPureHostPersonality(n string, personality string, exists bool)
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name, ok := rs.Primary.Attributes["name"]
 		h, err := client.Hosts.GetHost(name, map[string]string{"personality": "true"})
 		if err != nil {
@@ -0,0 +1,129 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourcePureHostVolumeConnection connects a volume to a host independently
+// of the volume block of the purefa_host resource, so that the owner of the
+// volume does not need to own the host.
+func resourcePureHostVolumeConnection() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureHostVolumeConnectionCreate,
+		ReadContext:   resourcePureHostVolumeConnectionRead,
+		DeleteContext: resourcePureHostVolumeConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the host.",
+			},
+			"volume": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Full name of the volume.",
+			},
+			"lun": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 16383),
+				Description:  "LUN of the volume. The array assigns the lowest free LUN when it is not set.",
+			},
+		},
+	}
+}
+
+func resourcePureHostVolumeConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	host := d.Get("host").(string)
+	volume := d.Get("volume").(string)
+	data := make(map[string]interface{})
+	if lun, ok := d.GetOk("lun"); ok {
+		data["lun"] = lun.(int)
+	}
+	if _, err := client.Hosts.ConnectHost(host, volume, data); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(volumeConnectionID(host, volume))
+	return resourcePureHostVolumeConnectionRead(ctx, d, m)
+}
+
+func resourcePureHostVolumeConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	host, volume, err := parseVolumeConnectionID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volumes, _ := client.Hosts.ListHostConnections(host, map[string]string{"private": "true"})
+	for _, v := range volumes {
+		if v.Vol == volume {
+			d.Set("host", host)
+			d.Set("volume", volume)
+			d.Set("lun", v.Lun)
+			return nil
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePureHostVolumeConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Hosts.DisconnectHost(d.Get("host").(string), d.Get("volume").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// volumeConnectionID returns the ID of the connection of a volume to a host
+// or host group. Host and host group names cannot contain a '/', so the
+// volume may be in a volume group.
+func volumeConnectionID(host string, volume string) string {
+	return fmt.Sprintf("%s/%s", host, volume)
+}
+
+func parseVolumeConnectionID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid connection ID '%s', expected <host>/<volume>", id)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,138 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureHostVolumeConnectionResourceName = "purefa_host_volume_connection.tfhostconnectiontest"
+
+// Connect a volume to a host with an assigned LUN
+func TestAccResourcePureHostVolumeConnection_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureHostVolumeConnectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureHostVolumeConnectionConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureHostVolumeConnectionResourceName, "host", fmt.Sprintf("tfhostconnectiontest%d", rInt)),
+					resource.TestCheckResourceAttrSet(testAccCheckPureHostVolumeConnectionResourceName, "lun"),
+				),
+			},
+			{
+				ResourceName:      testAccCheckPureHostVolumeConnectionResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Connect volumes to a host next to its volume block on the fake array.
+func TestResourcePureHostVolumeConnection_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureHostVolumeConnection()
+
+	for _, name := range []string{"tfconnvol1", "vg1/tfconnvol2", "tfconnvol3"} {
+		fa.volumes[name] = &fakeVolume{Name: name, Size: 1024000000}
+	}
+	host := testFakeApply(t, resourcePureHost(), nil, map[string]interface{}{
+		"name":   "tfhostconnectiontest",
+		"volume": []interface{}{map[string]interface{}{"vol": "tfconnvol3", "lun": 1}},
+	}, client)
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{"host": "tfhostconnectiontest", "volume": "tfconnvol1"}, client)
+	if state.ID != "tfhostconnectiontest/tfconnvol1" || state.Attributes["lun"] != "2" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+	state2 := testFakeApply(t, r, nil, map[string]interface{}{"host": "tfhostconnectiontest", "volume": "vg1/tfconnvol2", "lun": 10}, client)
+	if fa.hosts["tfhostconnectiontest"].Volumes["vg1/tfconnvol2"] != 10 {
+		t.Fatalf("unexpected connections: %v", fa.hosts["tfhostconnectiontest"].Volumes)
+	}
+
+	// The host ignores the connections of the connection resources.
+	if refreshed := testFakeRefresh(t, resourcePureHost(), host, client); refreshed.Attributes["volume.#"] != "1" {
+		t.Fatalf("unexpected host volumes: %v", refreshed)
+	}
+
+	testFakeApplyError(t, r, nil, map[string]interface{}{"host": "tfhostconnectiontest", "volume": "tfconnvol3"}, client)
+
+	// A new LUN reconnects the volume.
+	state2 = testFakeApply(t, r, state2, map[string]interface{}{"host": "tfhostconnectiontest", "volume": "vg1/tfconnvol2", "lun": 11}, client)
+	if fa.hosts["tfhostconnectiontest"].Volumes["vg1/tfconnvol2"] != 11 {
+		t.Fatalf("unexpected connections: %v", fa.hosts["tfhostconnectiontest"].Volumes)
+	}
+
+	testFakeImportVerify(t, r, state2.ID, state2, client)
+
+	testFakeDestroy(t, r, state, client)
+	testFakeDestroy(t, r, state2, client)
+	if volumes := fa.hosts["tfhostconnectiontest"].Volumes; len(volumes) != 1 || volumes["tfconnvol3"] != 1 {
+		t.Fatalf("unexpected connections after destroy: %v", volumes)
+	}
+
+	delete(fa.hosts["tfhostconnectiontest"].Volumes, "tfconnvol3")
+	if testFakeRefresh(t, r, state, client) != nil {
+		t.Fatalf("connection still exists")
+	}
+}
+
+func testAccCheckPureHostVolumeConnectionDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_host_volume_connection" {
+			continue
+		}
+
+		volumes, _ := client.Hosts.ListHostConnections(rs.Primary.Attributes["host"], map[string]string{"private": "true"})
+		for _, v := range volumes {
+			if v.Vol == rs.Primary.Attributes["volume"] {
+				return fmt.Errorf("connection '%s' still exists", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPureHostVolumeConnectionConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_volume" "tfhostconnectiontest" {
+	name = "tfhostconnectiontest-volume-%d"
+	size = 1024000000
+}
+
+resource "purefa_host" "tfhostconnectiontest" {
+	name = "tfhostconnectiontest%d"
+}
+
+resource "purefa_host_volume_connection" "tfhostconnectiontest" {
+	host   = purefa_host.tfhostconnectiontest.name
+	volume = purefa_volume.tfhostconnectiontest.name
+}`, rInt, rInt)
+}
@@ -16,10 +16,11 @@
 
 package purestorage
 
-// The Pure API 1.x does not support setting network interfaces. Therefore this resource is disabled.
-/*
 import (
 	"context"
+	"fmt"
+	"net"
+	"regexp"
 
 	"github.com/devans10/pugo/flasharray"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -27,12 +28,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// resourcePureNetworkInterface manages the settings of an existing network
+// interface. Interfaces can not be created or deleted through the API, so
+// Create adopts the interface, and Delete disables it or leaves it as is.
+// Attributes that are not configured are left as they are on the array.
 func resourcePureNetworkInterface() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcePureNetworkInterfaceCreate,
 		ReadContext:   resourcePureNetworkInterfaceRead,
 		UpdateContext: resourcePureNetworkInterfaceUpdate,
 		DeleteContext: resourcePureNetworkInterfaceDelete,
+		CustomizeDiff: resourcePureNetworkInterfaceCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -48,35 +54,61 @@ func resourcePureNetworkInterface() *schema.Resource {
 				Description:  "Network address for the network interface",
 				Required:     false,
 				Optional:     true,
-				ValidateFunc: validation.IsIPAddress,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv4Address,
 			},
 			"gateway": {
 				Type:         schema.TypeString,
 				Description:  "Gateway address for the network interface",
 				Required:     false,
 				Optional:     true,
-				ValidateFunc: validation.IsIPAddress,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv4Address,
 			},
 			"netmask": {
 				Type:         schema.TypeString,
 				Description:  "Subnet mask i the form ddd.ddd.ddd.ddd (ex. 255.255.255.0)",
 				Required:     false,
 				Optional:     true,
-				ValidateFunc: validation.IsIPAddress,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv4Address,
 			},
 			"enabled": {
 				Type:        schema.TypeBool,
 				Description: "Enable/disable network interface",
 				Optional:    true,
-				Default:     false,
+				Computed:    true,
 			},
 			"mtu": {
 				Type:         schema.TypeInt,
 				Description:  "mtu for the network interface",
 				Optional:     true,
-				Default:      1500,
+				Computed:     true,
 				ValidateFunc: validation.IntBetween(568, 9000),
 			},
+			"disable_on_delete": {
+				Type:        schema.TypeBool,
+				Description: "Disable the network interface when the resource is deleted. When set to false, the interface is left as it is.",
+				Optional:    true,
+				Default:     false,
+			},
+			"services": {
+				Type:        schema.TypeList,
+				Description: "Services of the network interface",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "Subnet of the network interface",
+				Computed:    true,
+			},
+			"slaves": {
+				Type:        schema.TypeList,
+				Description: "Slave interfaces of a bond interface",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"mac": {
 				Type:        schema.TypeString,
 				Description: "mac address",
@@ -102,70 +134,71 @@ func resourcePureNetworkInterface() *schema.Resource {
 	}
 }
 
-func resourcePureNetworkInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+// physicalInterfaceRegexp matches the names of the physical ethernet ports of
+// the controllers, such as ct0.eth2.
+var physicalInterfaceRegexp = regexp.MustCompile(`^ct[0-9]+\.eth[0-9]+$`)
 
+// resourcePureNetworkInterfaceCreate adopts an existing physical network
+// interface, and sets the configured attributes that differ from the array.
+func resourcePureNetworkInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*flasharray.Client)
-	name, _ := d.GetOk("name")
-	data := make(map[string]interface{})
 
-	if d.Get("enabled").(bool) {
-		data["enabled"] = true
-		data["mtu"] = d.Get("mtu").(int)
-		if address, ok := d.GetOk("address"); ok {
-			data["address"] = address
-		}
+	netInterface, err := client.Networks.GetNetworkInterface(d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !physicalInterfaceRegexp.MatchString(netInterface.Name) || len(netInterface.Slaves) > 0 {
+		return diag.Errorf("network interface %s is not a physical interface, only the ct<N>.eth<N> ports can be managed", netInterface.Name)
+	}
+	d.SetId(netInterface.Name)
 
-		if netmask, ok := d.GetOk("netmask"); ok {
-			data["netmask"] = netmask
-		}
+	data := make(map[string]interface{})
+	if address, ok := d.GetOk("address"); ok && address.(string) != netInterface.Address {
+		data["address"] = address
+	}
+	if netmask, ok := d.GetOk("netmask"); ok && netmask.(string) != netInterface.Netmask {
+		data["netmask"] = netmask
+	}
+	if gateway, ok := d.GetOk("gateway"); ok && gateway.(string) != netInterface.Gateway {
+		data["gateway"] = gateway
+	}
+	if mtu, ok := d.GetOk("mtu"); ok && mtu.(int) != netInterface.Mtu {
+		data["mtu"] = mtu
+	}
+	if enabled, ok := d.GetOkExists("enabled"); ok && enabled.(bool) != netInterface.Enabled {
+		data["enabled"] = enabled
+	}
 
-		if gateway, ok := d.GetOk("gateway"); ok {
-			data["gateway"] = gateway
+	if len(data) > 0 {
+		if _, err := client.Networks.SetNetworkInterface(d.Id(), data); err != nil {
+			return diag.FromErr(err)
 		}
-	} else {
-		data["enabled"] = false
 	}
 
-	netInterface, err := client.Networks.SetNetworkInterface(name.(string), data)
-	if err != nil {
-		return diag.FromErr(err)
+	return resourcePureNetworkInterfaceRead(ctx, d, m)
+}
+
+func resourcePureNetworkInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	netInterface, _ := client.Networks.GetNetworkInterface(d.Id())
+	if netInterface == nil {
+		d.SetId("")
+		return nil
 	}
 
 	d.Set("name", netInterface.Name)
 	d.Set("address", netInterface.Address)
 	d.Set("netmask", netInterface.Netmask)
 	d.Set("gateway", netInterface.Gateway)
+	d.Set("enabled", netInterface.Enabled)
 	d.Set("mtu", netInterface.Mtu)
+	d.Set("services", netInterface.Services)
+	d.Set("subnet", netInterface.Subnet)
+	d.Set("slaves", netInterface.Slaves)
 	d.Set("speed", netInterface.Speed)
 	d.Set("speed_gbs", netInterface.Speed/1000000000)
 	d.Set("mac", netInterface.Hwaddr)
-	d.SetId(netInterface.Name)
-
-	return nil
-}
-
-func resourcePureNetworkInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*flasharray.Client)
-
-	name := d.Id()
-
-	netInterface, err := client.Networks.GetNetworkInterface(name)
-	if err != nil {
-		return diag.FromErr(err)
-	} else if netInterface == nil {
-		d.SetId("")
-	} else {
-		d.Set("name", netInterface.Name)
-		d.Set("address", netInterface.Address)
-		d.Set("netmask", netInterface.Netmask)
-		d.Set("gateway", netInterface.Gateway)
-		d.Set("mtu", netInterface.Mtu)
-		d.Set("speed", netInterface.Speed)
-		d.Set("speed_gbs", netInterface.Speed/1000000000)
-		d.Set("mac", netInterface.Hwaddr)
-		d.SetId(netInterface.Name)
-	}
-
 	return nil
 }
 
@@ -173,52 +206,78 @@ func resourcePureNetworkInterfaceUpdate(ctx context.Context, d *schema.ResourceD
 	client := m.(*flasharray.Client)
 	data := make(map[string]interface{})
 
-	if d.HasChange("mtu") {
-		data["mtu"] = d.Get("mtu").(int)
-	}
-
-	if d.HasChange("address") {
-		data["address"] = d.Get("address").(string)
-	}
-
-	if d.HasChange("netmask") {
-		data["netmask"] = d.Get("netmask").(string)
-	}
-
-	if d.HasChange("gateway") {
-		data["gateway"] = d.Get("gateway").(string)
+	for _, k := range []string{"address", "netmask", "gateway", "mtu", "enabled"} {
+		if d.HasChange(k) {
+			data[k] = d.Get(k)
+		}
 	}
 
-	if len(data) > 0 || d.HasChange("enabled") {
-		data["enabled"] = d.Get("enabled")
-		netInterface, err := client.Networks.SetNetworkInterface(d.Id(), data)
-		if err != nil {
+	if len(data) > 0 {
+		if _, err := client.Networks.SetNetworkInterface(d.Id(), data); err != nil {
 			return diag.FromErr(err)
 		}
-
-		d.Set("name", netInterface.Name)
-		d.Set("address", netInterface.Address)
-		d.Set("netmask", netInterface.Netmask)
-		d.Set("gateway", netInterface.Gateway)
-		d.Set("mtu", netInterface.Mtu)
-		d.Set("speed", netInterface.Speed)
-		d.Set("speed_gbs", netInterface.Speed/1000000000)
-		d.Set("mac", netInterface.Hwaddr)
-
 	}
 
-	return nil
+	return resourcePureNetworkInterfaceRead(ctx, d, m)
 }
 
+// resourcePureNetworkInterfaceDelete leaves the network interface as it is,
+// or disables it if disable_on_delete is set. The interface itself is never
+// removed.
 func resourcePureNetworkInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*flasharray.Client)
 
-	if _, err := client.Networks.DisableNetworkInterface(d.Id()); err != nil {
-		return diag.FromErr(err)
+	if d.Get("disable_on_delete").(bool) {
+		if _, err := client.Networks.DisableNetworkInterface(d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	d.SetId("")
 	return nil
 }
 
-*/
+// resourcePureNetworkInterfaceCustomizeDiff validates the planned address,
+// netmask and gateway together. The check is skipped while one of them is
+// only known after the interface is adopted.
+func resourcePureNetworkInterfaceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for _, k := range []string{"address", "netmask", "gateway"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+	return validateNetworkInterfaceAddress(d.Get("address").(string), d.Get("netmask").(string), d.Get("gateway").(string))
+}
+
+// validateNetworkInterfaceAddress checks that the netmask is a valid IPv4
+// netmask, and that the gateway is in the network of the address.
+func validateNetworkInterfaceAddress(address string, netmask string, gateway string) error {
+	var mask net.IPMask
+	if netmask != "" {
+		ip := net.ParseIP(netmask).To4()
+		if ip == nil {
+			return fmt.Errorf("netmask %q is not an IPv4 address", netmask)
+		}
+		mask = net.IPMask(ip)
+		if ones, bits := mask.Size(); ones == 0 && bits == 0 {
+			return fmt.Errorf("netmask %q is not a valid netmask", netmask)
+		}
+	}
+	if address != "" && mask == nil {
+		return fmt.Errorf("netmask must be set with address %q", address)
+	}
+	if gateway == "" {
+		return nil
+	}
+	if address == "" {
+		return fmt.Errorf("address must be set with gateway %q", gateway)
+	}
+	if gateway == address {
+		return fmt.Errorf("gateway %q can not be the address of the interface", gateway)
+	}
+	network := net.IPNet{IP: net.ParseIP(address).Mask(mask), Mask: mask}
+	if !network.Contains(net.ParseIP(gateway)) {
+		return fmt.Errorf("gateway %q is not in the network %s of address %q", gateway, network.String(), address)
+	}
+	return nil
+}
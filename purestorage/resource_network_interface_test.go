@@ -14,14 +14,19 @@
    limitations under the License.
 */
 
-// The Pure API 1.x does not support setting network interfaces. Therefore this resource is disabled.
-
 package purestorage
 
-/*
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
 // Configure Network interfaces settings
 func TestAccResourcePureNetworkInterface_create(t *testing.T) {
-	ifname := "vir1"
+	ifname := "ct0.eth3"
 	address1 := "192.168.6.3"
 	address2 := "192.168.6.4"
 	gateway := "192.168.6.1"
@@ -35,7 +40,7 @@ func TestAccResourcePureNetworkInterface_create(t *testing.T) {
 			{
 				Config: testAccCheckPureNetworkInterfaceConfig(ifname, address1, gateway, netmask, true, 1500),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resource_name, "ifname", ifname),
+					resource.TestCheckResourceAttr(resource_name, "name", ifname),
 					resource.TestCheckResourceAttr(resource_name, "address", address1),
 					resource.TestCheckResourceAttr(resource_name, "gateway", gateway),
 					resource.TestCheckResourceAttr(resource_name, "netmask", netmask),
@@ -46,29 +51,94 @@ func TestAccResourcePureNetworkInterface_create(t *testing.T) {
 			{
 				Config: testAccCheckPureNetworkInterfaceConfig(ifname, address1, gateway, netmask, false, 1500),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resource_name, "ifname", ifname),
-					resource.TestCheckResourceAttr(resource_name, "address", address1),
-					resource.TestCheckResourceAttr(resource_name, "gateway", gateway),
-					resource.TestCheckResourceAttr(resource_name, "netmask", netmask),
+					resource.TestCheckResourceAttr(resource_name, "name", ifname),
 					resource.TestCheckResourceAttr(resource_name, "enabled", "false"),
-					resource.TestCheckResourceAttr(resource_name, "mtu", "1500"),
 				),
 			},
 			{
 				Config: testAccCheckPureNetworkInterfaceConfig(ifname, address2, gateway, netmask, true, 9000),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resource_name, "ifname", ifname),
+					resource.TestCheckResourceAttr(resource_name, "name", ifname),
 					resource.TestCheckResourceAttr(resource_name, "address", address2),
-					resource.TestCheckResourceAttr(resource_name, "gateway", gateway),
-					resource.TestCheckResourceAttr(resource_name, "netmask", netmask),
 					resource.TestCheckResourceAttr(resource_name, "enabled", "true"),
-					resource.TestCheckResourceAttr(resource_name, "mtu", "1500"),
+					resource.TestCheckResourceAttr(resource_name, "mtu", "9000"),
 				),
 			},
 		},
 	})
 }
 
+// Adopt an interface, change it, and release it against the fake array.
+func TestResourcePureNetworkInterface_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureNetworkInterface()
+
+	testFakeApplyError(t, r, nil, map[string]interface{}{"name": "ct0.eth9"}, client)
+	testFakeApplyError(t, r, nil, map[string]interface{}{"name": "vir0"}, client)
+
+	// Adopting an interface leaves the attributes that are not configured,
+	// and the interface is left enabled when the resource is destroyed.
+	state := testFakeApply(t, r, nil, map[string]interface{}{"name": "ct0.eth0"}, client)
+	if state.Attributes["address"] != "10.0.0.11" || state.Attributes["enabled"] != "true" || state.Attributes["services.0"] != "management" {
+		t.Fatalf("unexpected state after adopt: %v", state)
+	}
+	testFakeDestroy(t, r, state, client)
+	if !fa.interfaces["ct0.eth0"].Enabled {
+		t.Fatalf("interface was disabled")
+	}
+
+	config := map[string]interface{}{
+		"name":    "ct0.eth2",
+		"address": "192.168.6.3",
+		"netmask": "255.255.255.0",
+		"gateway": "192.168.6.1",
+		"enabled": true,
+		"mtu":     9000,
+
+		"disable_on_delete": true,
+	}
+	state = testFakeApply(t, r, nil, config, client)
+	if iface := fa.interfaces["ct0.eth2"]; !iface.Enabled || iface.Address != "192.168.6.3" || iface.Mtu != 9000 {
+		t.Fatalf("unexpected interface after create: %#v", iface)
+	}
+
+	config["address"] = "192.168.6.4"
+	state = testFakeApply(t, r, state, config, client)
+
+	config["gateway"] = "192.168.7.1"
+	testFakeApplyError(t, r, state, config, client)
+
+	testFakeImportVerify(t, r, state.ID, state, client, "disable_on_delete")
+
+	testFakeDestroy(t, r, state, client)
+	if iface := fa.interfaces["ct0.eth2"]; iface.Enabled || iface.Address != "192.168.6.4" {
+		t.Fatalf("interface was not disabled: %#v", iface)
+	}
+}
+
+func TestValidateNetworkInterfaceAddress(t *testing.T) {
+	cases := []struct {
+		address, netmask, gateway string
+		valid                     bool
+	}{
+		{"", "", "", true},
+		{"192.168.6.3", "255.255.255.0", "", true},
+		{"192.168.6.3", "255.255.255.0", "192.168.6.1", true},
+		{"192.168.6.3", "255.255.254.0", "192.168.7.1", true},
+		{"192.168.6.3", "", "", false},
+		{"192.168.6.3", "255.0.255.0", "", false},
+		{"192.168.6.3", "255.255.255.0", "192.168.7.1", false},
+		{"192.168.6.3", "255.255.255.0", "192.168.6.3", false},
+		{"", "255.255.255.0", "192.168.6.1", false},
+	}
+	for _, c := range cases {
+		err := validateNetworkInterfaceAddress(c.address, c.netmask, c.gateway)
+		if (err == nil) != c.valid {
+			t.Errorf("validateNetworkInterfaceAddress(%q, %q, %q) = %v, expected valid %t", c.address, c.netmask, c.gateway, err, c.valid)
+		}
+	}
+}
+
 func testAccCheckPureNetworkInterfaceConfig(ifname string, address string, gateway string, netmask string, enabled bool, mtu int) string {
 	return fmt.Sprintf(`
 			resource "purefa_network_interface" "tfnetworkinterface%stest" {
@@ -81,4 +151,3 @@ func testAccCheckPureNetworkInterfaceConfig(ifname string, address string, gatew
 			}`, strings.Replace(ifname, ".", "_", -1), ifname, address, gateway, netmask, enabled, mtu)
 
 }
-*/
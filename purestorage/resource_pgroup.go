@@ -18,6 +18,7 @@ package purestorage
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/devans10/pugo/flasharray"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -25,7 +26,7 @@ import (
 )
 
 func resourcePureProtectiongroup() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourcePureProtectiongroupCreate,
 		ReadContext:   resourcePureProtectiongroupRead,
 		UpdateContext: resourcePureProtectiongroupUpdate,
@@ -33,6 +34,7 @@ func resourcePureProtectiongroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourcePureProtectiongroupImport,
 		},
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -40,7 +42,7 @@ func resourcePureProtectiongroup() *schema.Resource {
 				Required: true,
 			},
 			"hosts": {
-				Type: schema.TypeList,
+				Type: schema.TypeSet,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -49,7 +51,7 @@ func resourcePureProtectiongroup() *schema.Resource {
 				Default:       nil,
 			},
 			"volumes": {
-				Type: schema.TypeList,
+				Type: schema.TypeSet,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -58,7 +60,7 @@ func resourcePureProtectiongroup() *schema.Resource {
 				Default:       nil,
 			},
 			"hgroups": {
-				Type: schema.TypeList,
+				Type: schema.TypeSet,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -66,13 +68,25 @@ func resourcePureProtectiongroup() *schema.Resource {
 				Optional:      true,
 				Default:       nil,
 			},
-			"targets": {
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeMap,
+			"target": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Replication targets of the protection group.",
+				Set:         pgroupTargetHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the connected target array.",
+						},
+						"allowed": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the target array allows the protection group to be replicated to it.",
+						},
+					},
 				},
-				Optional: true,
-				Default:  nil,
 			},
 			"source": {
 				Type:     schema.TypeString,
@@ -157,8 +171,80 @@ func resourcePureProtectiongroup() *schema.Resource {
 				Optional:    true,
 				Default:     4,
 			},
+			"eradicate_on_delete": {
+				Type:        schema.TypeBool,
+				Description: "When set to true, the protection group is eradicated after it is destroyed instead of waiting for the eradication timer.",
+				Optional:    true,
+				Default:     false,
+			},
+			"recover_if_destroyed": {
+				Type:        schema.TypeBool,
+				Description: "When set to true, a destroyed protection group with the same name is recovered instead of creating a new protection group.",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourcePureProtectiongroupV0(r).CoreConfigSchema().ImpliedType(),
+			Upgrade: resourcePureProtectiongroupStateUpgradeV0,
 		},
 	}
+	return r
+}
+
+// resourcePureProtectiongroupV0 returns the version 0 of the protection
+// group, with the member lists and the targets list of maps.
+func resourcePureProtectiongroupV0(r *schema.Resource) *schema.Resource {
+	s := make(map[string]*schema.Schema, len(r.Schema))
+	for k, v := range r.Schema {
+		s[k] = v
+	}
+	for _, k := range []string{"hosts", "volumes", "hgroups"} {
+		s[k] = &schema.Schema{
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Optional: true,
+		}
+	}
+	delete(s, "target")
+	s["targets"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Elem:     &schema.Schema{Type: schema.TypeMap},
+		Optional: true,
+	}
+	return &schema.Resource{Schema: s}
+}
+
+// resourcePureProtectiongroupStateUpgradeV0 moves the targets of a version 0
+// protection group to the target blocks.
+func resourcePureProtectiongroupStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	target := make([]interface{}, 0)
+	targets, _ := rawState["targets"].([]interface{})
+	for _, t := range targets {
+		t, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, ok := t["name"].(string)
+		if !ok {
+			continue
+		}
+		// The allowed flag was stored as a string in the map.
+		var allowed bool
+		switch a := t["allowed"].(type) {
+		case bool:
+			allowed = a
+		case string:
+			allowed, _ = strconv.ParseBool(a)
+		}
+		target = append(target, map[string]interface{}{"name": name, "allowed": allowed})
+	}
+	delete(rawState, "targets")
+	rawState["target"] = target
+	return rawState, nil
 }
 
 func resourcePureProtectiongroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -171,7 +257,7 @@ func resourcePureProtectiongroupCreate(ctx context.Context, d *schema.ResourceDa
 
 	if h, ok := d.GetOk("hosts"); ok {
 		var hosts []string
-		for _, element := range h.([]interface{}) {
+		for _, element := range h.(*schema.Set).List() {
 			hosts = append(hosts, element.(string))
 		}
 		data["hostlist"] = hosts
@@ -179,7 +265,7 @@ func resourcePureProtectiongroupCreate(ctx context.Context, d *schema.ResourceDa
 
 	if v, ok := d.GetOk("volumes"); ok {
 		var volumes []string
-		for _, element := range v.([]interface{}) {
+		for _, element := range v.(*schema.Set).List() {
 			volumes = append(volumes, element.(string))
 		}
 		data["vollist"] = volumes
@@ -187,21 +273,30 @@ func resourcePureProtectiongroupCreate(ctx context.Context, d *schema.ResourceDa
 
 	if hg, ok := d.GetOk("hgroups"); ok {
 		var hgroups []string
-		for _, element := range hg.([]interface{}) {
+		for _, element := range hg.(*schema.Set).List() {
 			hgroups = append(hgroups, element.(string))
 		}
 		data["hgrouplist"] = hgroups
 	}
 
-	if t, ok := d.GetOk("targets"); ok {
-		var targets []string
-		for _, element := range t.([]interface{}) {
-			targets = append(targets, element.(string))
-		}
-		data["targetlist"] = targets
+	if t, ok := d.GetOk("target"); ok {
+		data["targetlist"] = pgroupTargetNames(t.(*schema.Set))
 	}
 
-	if pgroup, err = client.Protectiongroups.CreateProtectiongroup(d.Get("name").(string), data); err != nil {
+	var destroyed *flasharray.Protectiongroup
+	if d.Get("recover_if_destroyed").(bool) {
+		destroyed, _ = client.Protectiongroups.GetProtectiongroup(d.Get("name").(string), map[string]string{"pending_only": "true"})
+	}
+	if destroyed != nil {
+		if pgroup, err = client.Protectiongroups.RecoverProtectiongroup(destroyed.Name); err != nil {
+			return diag.FromErr(err)
+		}
+		if len(data) > 0 {
+			if _, err = client.Protectiongroups.SetProtectiongroup(pgroup.Name, data); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	} else if pgroup, err = client.Protectiongroups.CreateProtectiongroup(d.Get("name").(string), data); err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(pgroup.Name)
@@ -215,9 +310,6 @@ func resourcePureProtectiongroupCreate(ctx context.Context, d *schema.ResourceDa
 	if val, ok := data["hgrouplist"]; ok {
 		d.Set("hgroups", val)
 	}
-	if val, ok := data["targetlist"]; ok {
-		d.Set("targets", val)
-	}
 
 	retentionData := make(map[string]interface{})
 	if allFor, ok := d.GetOk("all_for"); ok {
@@ -321,12 +413,15 @@ func resourcePureProtectiongroupRead(ctx context.Context, d *schema.ResourceData
 		return nil
 	}
 
+	// Only the members of the member lists are managed by the protection
+	// group, so that they can coexist with the protection group member
+	// resources.
 	d.Set("name", p.Name)
-	d.Set("hosts", p.Hosts)
-	d.Set("volumes", p.Volumes)
-	d.Set("hgroups", p.Hgroups)
+	d.Set("hosts", managedPgroupMembers(d.Get("hosts").(*schema.Set), p.Hosts))
+	d.Set("volumes", managedPgroupMembers(d.Get("volumes").(*schema.Set), p.Volumes))
+	d.Set("hgroups", managedPgroupMembers(d.Get("hgroups").(*schema.Set), p.Hgroups))
 	d.Set("source", p.Source)
-	d.Set("targets", p.Targets)
+	d.Set("target", flattenPgroupTargets(p.Targets))
 
 	params := map[string]string{"schedule": "true"}
 	s, _ := client.Protectiongroups.GetProtectiongroup(d.Id(), params)
@@ -353,6 +448,33 @@ func resourcePureProtectiongroupRead(ctx context.Context, d *schema.ResourceData
 	return nil
 }
 
+// managedPgroupMembers returns the members of a member set that are still
+// members of the protection group.
+func managedPgroupMembers(set *schema.Set, members []string) []string {
+	var out []string
+	for _, element := range set.List() {
+		if stringInSlice(element.(string), members) {
+			out = append(out, element.(string))
+		}
+	}
+	return out
+}
+
+// pgroupMemberChange returns the members removed from and added to a member
+// set.
+func pgroupMemberChange(d *schema.ResourceData, k string) ([]string, []string) {
+	o, n := d.GetChange(k)
+	os, ns := o.(*schema.Set), n.(*schema.Set)
+	var removed, added []string
+	for _, element := range os.Difference(ns).List() {
+		removed = append(removed, element.(string))
+	}
+	for _, element := range ns.Difference(os).List() {
+		added = append(added, element.(string))
+	}
+	return removed, added
+}
+
 func resourcePureProtectiongroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var pgroup *flasharray.Protectiongroup
@@ -367,56 +489,45 @@ func resourcePureProtectiongroupUpdate(ctx context.Context, d *schema.ResourceDa
 		d.Set("name", pgroup.Name)
 	}
 
-	data := make(map[string]interface{})
-	if d.HasChange("hosts") {
-		var hosts []string
-		for _, element := range d.Get("hosts").([]interface{}) {
-			hosts = append(hosts, element.(string))
+	// The members are added and removed one by one, so that the members
+	// added by the protection group member resources are kept. All members
+	// are removed first, since a protection group only contains one type of
+	// member.
+	for _, k := range []string{"hosts", "volumes", "hgroups"} {
+		removed, _ := pgroupMemberChange(d, k)
+		for _, member := range removed {
+			if err = pgroupMemberKinds[k].remove(client, member, d.Id()); err != nil {
+				return diag.FromErr(err)
+			}
 		}
-		data["hostlist"] = hosts
 	}
-
-	if d.HasChange("volumes") {
-		var volumes []string
-		for _, element := range d.Get("volumes").([]interface{}) {
-			volumes = append(volumes, element.(string))
+	for _, k := range []string{"hosts", "volumes", "hgroups"} {
+		_, added := pgroupMemberChange(d, k)
+		for _, member := range added {
+			if err = pgroupMemberKinds[k].add(client, member, d.Id()); err != nil {
+				return diag.FromErr(err)
+			}
 		}
-		data["vollist"] = volumes
 	}
 
-	if d.HasChange("hgroups") {
-		var hgroups []string
-		for _, element := range d.Get("hgroups").([]interface{}) {
-			hgroups = append(hgroups, element.(string))
+	// The targets are added and removed instead of replaced, so that the
+	// targets that stay keep being allowed.
+	if d.HasChange("target") {
+		o, n := d.GetChange("target")
+		oldTargets, newTargets := pgroupTargetNames(o.(*schema.Set)), pgroupTargetNames(n.(*schema.Set))
+		data := make(map[string]interface{})
+		if removed := difference(oldTargets, newTargets); len(removed) > 0 {
+			data["remtargetlist"] = removed
 		}
-		data["hgrouplist"] = hgroups
-	}
-
-	if d.HasChange("targets") {
-		var targets []string
-		for _, element := range d.Get("targets").([]interface{}) {
-			targets = append(targets, element.(string))
+		if added := difference(newTargets, oldTargets); len(added) > 0 {
+			data["addtargetlist"] = added
 		}
-		data["targetlist"] = targets
-	}
-
-	if len(data) > 0 {
-		if _, err = client.Protectiongroups.SetProtectiongroup(d.Id(), data); err != nil {
-			return diag.FromErr(err)
+		if len(data) > 0 {
+			if _, err = client.Protectiongroups.SetProtectiongroup(d.Id(), data); err != nil {
+				return diag.FromErr(err)
+			}
 		}
 	}
-	if val, ok := data["hostlist"]; ok {
-		d.Set("hosts", val)
-	}
-	if val, ok := data["vollist"]; ok {
-		d.Set("volumes", val)
-	}
-	if val, ok := data["hgrouplist"]; ok {
-		d.Set("hgroups", val)
-	}
-	if val, ok := data["targetlist"]; ok {
-		d.Set("targets", val)
-	}
 
 	retentionData := make(map[string]interface{})
 	if d.HasChange("all_for") {
@@ -522,6 +633,12 @@ func resourcePureProtectiongroupDelete(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
+	if d.Get("eradicate_on_delete").(bool) {
+		if _, err := client.Protectiongroups.EradicateProtectiongroup(d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
@@ -540,7 +657,7 @@ func resourcePureProtectiongroupImport(d *schema.ResourceData, m interface{}) ([
 	d.Set("volumes", p.Volumes)
 	d.Set("hgroups", p.Hgroups)
 	d.Set("source", p.Source)
-	d.Set("targets", p.Targets)
+	d.Set("target", flattenPgroupTargets(p.Targets))
 
 	params := map[string]string{"schedule": "true"}
 	s, _ := client.Protectiongroups.GetProtectiongroup(d.Id(), params)
@@ -0,0 +1,164 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// pgroupMemberKind is a type of member of a protection group. A protection
+// group contains either volumes, hosts or host groups.
+type pgroupMemberKind struct {
+	// attr is the attribute of the member in the member resource.
+	attr        string
+	description string
+	members     func(p *flasharray.Protectiongroup) []string
+	add         func(client *flasharray.Client, member string, pgroup string) error
+	remove      func(client *flasharray.Client, member string, pgroup string) error
+}
+
+// pgroupMemberKinds are the member kinds, keyed by the member list of the
+// purefa_protectiongroup resource.
+var pgroupMemberKinds = map[string]pgroupMemberKind{
+	"volumes": {
+		attr:        "volume",
+		description: "Full name of the volume.",
+		members:     func(p *flasharray.Protectiongroup) []string { return p.Volumes },
+		add: func(client *flasharray.Client, member string, pgroup string) error {
+			_, err := client.Volumes.AddVolume(member, pgroup)
+			return err
+		},
+		remove: func(client *flasharray.Client, member string, pgroup string) error {
+			_, err := client.Volumes.RemoveVolume(member, pgroup)
+			return err
+		},
+	},
+	"hosts": {
+		attr:        "host",
+		description: "Name of the host.",
+		members:     func(p *flasharray.Protectiongroup) []string { return p.Hosts },
+		add: func(client *flasharray.Client, member string, pgroup string) error {
+			_, err := client.Hosts.AddHost(member, pgroup)
+			return err
+		},
+		remove: func(client *flasharray.Client, member string, pgroup string) error {
+			_, err := client.Hosts.RemoveHost(member, pgroup)
+			return err
+		},
+	},
+	"hgroups": {
+		attr:        "hostgroup",
+		description: "Name of the host group.",
+		members:     func(p *flasharray.Protectiongroup) []string { return p.Hgroups },
+		add: func(client *flasharray.Client, member string, pgroup string) error {
+			_, err := client.Hostgroups.AddHostgroup(member, pgroup)
+			return err
+		},
+		remove: func(client *flasharray.Client, member string, pgroup string) error {
+			_, err := client.Hostgroups.RemoveHostgroup(member, pgroup)
+			return err
+		},
+	},
+}
+
+func resourcePureProtectiongroupVolume() *schema.Resource {
+	return resourcePureProtectiongroupMember(pgroupMemberKinds["volumes"])
+}
+
+func resourcePureProtectiongroupHost() *schema.Resource {
+	return resourcePureProtectiongroupMember(pgroupMemberKinds["hosts"])
+}
+
+func resourcePureProtectiongroupHostgroup() *schema.Resource {
+	return resourcePureProtectiongroupMember(pgroupMemberKinds["hgroups"])
+}
+
+// resourcePureProtectiongroupMember adds a member to a protection group
+// independently of the member lists of the purefa_protectiongroup resource.
+func resourcePureProtectiongroupMember(kind pgroupMemberKind) *schema.Resource {
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			client := m.(*flasharray.Client)
+
+			pgroup := d.Get("protection_group").(string)
+			member := d.Get(kind.attr).(string)
+			if err := kind.add(client, member, pgroup); err != nil {
+				return diag.FromErr(err)
+			}
+
+			d.SetId(fmt.Sprintf("%s/%s", pgroup, member))
+			return resourcePureProtectiongroupMemberRead(ctx, d, m, kind)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourcePureProtectiongroupMemberRead(ctx, d, m, kind)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			client := m.(*flasharray.Client)
+
+			if err := kind.remove(client, d.Get(kind.attr).(string), d.Get("protection_group").(string)); err != nil {
+				return diag.FromErr(err)
+			}
+
+			d.SetId("")
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"protection_group": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the protection group.",
+			},
+			kind.attr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: kind.description,
+			},
+		},
+	}
+}
+
+func resourcePureProtectiongroupMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}, kind pgroupMemberKind) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	// Protection group names cannot contain a '/', so the member may be a
+	// volume in a volume group.
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return diag.Errorf("invalid protection group member ID '%s', expected <protection_group>/<%s>", d.Id(), kind.attr)
+	}
+
+	p, _ := client.Protectiongroups.GetProtectiongroup(parts[0], nil)
+	if p == nil || !stringInSlice(parts[1], kind.members(p)) {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("protection_group", p.Name)
+	d.Set(kind.attr, parts[1])
+	return nil
+}
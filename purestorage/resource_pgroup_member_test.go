@@ -0,0 +1,162 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureProtectiongroupVolumeResourceName = "purefa_protectiongroup_volume.tfpgroupmembertest"
+
+// Add a volume to a protection group
+func TestAccResourcePureProtectiongroupVolume_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureProtectiongroupMemberDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureProtectiongroupVolumeConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureProtectiongroupVolumeResourceName, "protection_group", fmt.Sprintf("tfpgroupmembertest%d", rInt)),
+					resource.TestCheckResourceAttr(testAccCheckPureProtectiongroupVolumeResourceName, "volume", fmt.Sprintf("tfpgroupmembertestvol%d", rInt)),
+				),
+			},
+			{
+				ResourceName:      testAccCheckPureProtectiongroupVolumeResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Add volumes to a protection group next to its volumes list on the fake array.
+func TestResourcePureProtectiongroupVolume_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureProtectiongroupVolume()
+
+	for _, name := range []string{"tfmembervol1", "tfmembervol2", "tfmembervol3"} {
+		if _, err := client.Volumes.CreateVolume(name, 1024000000); err != nil {
+			t.Fatalf("error creating volume: %s", err)
+		}
+	}
+	pgroup := testFakeApply(t, resourcePureProtectiongroup(), nil, map[string]interface{}{
+		"name":    "tfpgroupmembertest",
+		"volumes": []interface{}{"tfmembervol1"},
+	}, client)
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{"protection_group": "tfpgroupmembertest", "volume": "tfmembervol2"}, client)
+	if state.ID != "tfpgroupmembertest/tfmembervol2" || !stringInSlice("tfmembervol2", fa.pgroups["tfpgroupmembertest"].Volumes) {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	// The protection group keeps the members it does not manage.
+	pgroup = testFakeApply(t, resourcePureProtectiongroup(), pgroup, map[string]interface{}{
+		"name":    "tfpgroupmembertest",
+		"volumes": []interface{}{"tfmembervol3"},
+	}, client)
+	if volumes := fmt.Sprint(fa.pgroups["tfpgroupmembertest"].Volumes); volumes != "[tfmembervol2 tfmembervol3]" {
+		t.Fatalf("unexpected protection group members: %s", volumes)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	testFakeDestroy(t, r, state, client)
+	if stringInSlice("tfmembervol2", fa.pgroups["tfpgroupmembertest"].Volumes) {
+		t.Fatalf("volume is still a member of the protection group")
+	}
+	if testFakeRefresh(t, r, state, client) != nil {
+		t.Fatalf("removed member was read")
+	}
+	testFakeDestroy(t, resourcePureProtectiongroup(), pgroup, client)
+}
+
+// Add hosts and host groups to protection groups on the fake array.
+func TestResourcePureProtectiongroupHost_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+
+	fa.hosts["tfmemberhost"] = &fakeHost{Name: "tfmemberhost", Volumes: map[string]int{}}
+	fa.hgroups["tfmemberhgroup"] = &fakeHgroup{Name: "tfmemberhgroup", Hosts: []string{}, Volumes: map[string]int{}}
+	hostPgroup := testFakeApply(t, resourcePureProtectiongroup(), nil, map[string]interface{}{"name": "tfpgroupmemberhosts"}, client)
+	hgroupPgroup := testFakeApply(t, resourcePureProtectiongroup(), nil, map[string]interface{}{"name": "tfpgroupmemberhgroups"}, client)
+
+	r := resourcePureProtectiongroupHost()
+	host := testFakeApply(t, r, nil, map[string]interface{}{"protection_group": "tfpgroupmemberhosts", "host": "tfmemberhost"}, client)
+	if fmt.Sprint(fa.pgroups["tfpgroupmemberhosts"].Hosts) != "[tfmemberhost]" {
+		t.Fatalf("host was not added to the protection group: %v", host)
+	}
+	testFakeImportVerify(t, r, host.ID, host, client)
+
+	hr := resourcePureProtectiongroupHostgroup()
+	hgroup := testFakeApply(t, hr, nil, map[string]interface{}{"protection_group": "tfpgroupmemberhgroups", "hostgroup": "tfmemberhgroup"}, client)
+	if fmt.Sprint(fa.pgroups["tfpgroupmemberhgroups"].Hgroups) != "[tfmemberhgroup]" {
+		t.Fatalf("host group was not added to the protection group: %v", hgroup)
+	}
+	testFakeImportVerify(t, hr, hgroup.ID, hgroup, client)
+
+	// A protection group contains only one type of member.
+	testFakeApplyError(t, hr, nil, map[string]interface{}{"protection_group": "tfpgroupmemberhosts", "hostgroup": "tfmemberhgroup"}, client)
+
+	testFakeDestroy(t, r, host, client)
+	testFakeDestroy(t, hr, hgroup, client)
+	if len(fa.pgroups["tfpgroupmemberhosts"].Hosts) != 0 || len(fa.pgroups["tfpgroupmemberhgroups"].Hgroups) != 0 {
+		t.Fatalf("members were not removed from the protection groups")
+	}
+	testFakeDestroy(t, resourcePureProtectiongroup(), hostPgroup, client)
+	testFakeDestroy(t, resourcePureProtectiongroup(), hgroupPgroup, client)
+}
+
+func testAccCheckPureProtectiongroupMemberDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_protectiongroup_volume" {
+			continue
+		}
+
+		if p, _ := client.Protectiongroups.GetProtectiongroup(rs.Primary.Attributes["protection_group"], nil); p != nil && stringInSlice(rs.Primary.Attributes["volume"], p.Volumes) {
+			return fmt.Errorf("protection group member '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPureProtectiongroupVolumeConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_protectiongroup" "tfpgroupmembertest" {
+	name = "tfpgroupmembertest%d"
+}
+
+resource "purefa_volume" "tfpgroupmembertest" {
+	name = "tfpgroupmembertestvol%d"
+	size = 1024000000
+}
+
+resource "purefa_protectiongroup_volume" "tfpgroupmembertest" {
+	protection_group = purefa_protectiongroup.tfpgroupmembertest.name
+	volume           = purefa_volume.tfpgroupmembertest.name
+}`, rInt, rInt)
+}
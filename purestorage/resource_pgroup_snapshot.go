@@ -0,0 +1,219 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePureProtectiongroupSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureProtectiongroupSnapshotCreate,
+		ReadContext:   resourcePureProtectiongroupSnapshotRead,
+		UpdateContext: resourcePureProtectiongroupSnapshotUpdate,
+		DeleteContext: resourcePureProtectiongroupSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePureProtectiongroupSnapshotImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"protection_group": {
+				Description: "Name of the protection group to snapshot.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"suffix": {
+				Description:  "Suffix of the snapshot name. The array generates a suffix when it is not set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[\w\-\d]+$`), "can only contain letters, numbers and '-'"),
+			},
+			"apply_retention": {
+				Description: "When set to true, the snapshot is retained according to the retention policy of the protection group. Otherwise it is kept until it is destroyed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+			},
+			"replicate_now": {
+				Description: "When set to true, the snapshot is replicated to the targets of the protection group immediately.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+			},
+			"eradicate_on_delete": {
+				Description: "When set to true, the snapshot is eradicated after it is destroyed instead of waiting for the eradication timer.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"name": {
+				Description: "Name of the snapshot, in the form pgroup.suffix.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created": {
+				Description: "Snapshot creation time.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"volume_snapshots": {
+				Description: "Names of the volume snapshots in the snapshot, keyed by volume name.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourcePureProtectiongroupSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	// The pugo sdk does not support the suffix, apply_retention and
+	// replicate_now options of a protection group snapshot.
+	data := map[string]interface{}{
+		"snap":   true,
+		"source": []string{d.Get("protection_group").(string)},
+	}
+	if suffix, ok := d.GetOk("suffix"); ok {
+		data["suffix"] = suffix.(string)
+	}
+	if d.Get("apply_retention").(bool) {
+		data["apply_retention"] = true
+	}
+	if d.Get("replicate_now").(bool) {
+		data["replicate_now"] = true
+	}
+
+	req, err := client.NewRequest("POST", "pgroup", nil, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	snapshots := []flasharray.ProtectiongroupSnapshot{}
+	if _, err := client.Do(req, &snapshots, false); err != nil {
+		return diag.FromErr(err)
+	}
+	if len(snapshots) != 1 {
+		return diag.Errorf("expected 1 protection group snapshot, got %d", len(snapshots))
+	}
+	d.SetId(snapshots[0].Name)
+
+	return resourcePureProtectiongroupSnapshotRead(ctx, d, m)
+}
+
+func resourcePureProtectiongroupSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	snapshot, _ := getPgroupSnapshot(client, d.Id())
+	if snapshot == nil {
+		d.SetId("")
+		return nil
+	}
+
+	volumes, err := listPgroupSnapshotVolumes(client, snapshot.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	volumeSnapshots := make(map[string]interface{})
+	for _, v := range volumes {
+		volumeSnapshots[v.Source] = v.Name
+	}
+
+	d.Set("protection_group", snapshot.Source)
+	d.Set("suffix", strings.TrimPrefix(snapshot.Name, snapshot.Source+"."))
+	d.Set("name", snapshot.Name)
+	d.Set("created", snapshot.Created)
+	d.Set("volume_snapshots", volumeSnapshots)
+	return nil
+}
+
+// resourcePureProtectiongroupSnapshotUpdate only has to handle
+// eradicate_on_delete, which is not stored on the array.
+func resourcePureProtectiongroupSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePureProtectiongroupSnapshotRead(ctx, d, m)
+}
+
+// resourcePureProtectiongroupSnapshotDelete destroys the snapshot, and
+// eradicates it if eradicate_on_delete is set.
+func resourcePureProtectiongroupSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Protectiongroups.DestroyProtectiongroup(d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("eradicate_on_delete").(bool) {
+		if _, err := client.Protectiongroups.EradicateProtectiongroup(d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePureProtectiongroupSnapshotImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if !strings.Contains(d.Id(), ".") {
+		return nil, fmt.Errorf("invalid protection group snapshot name '%s', expected pgroup.suffix", d.Id())
+	}
+	d.Set("apply_retention", false)
+	d.Set("replicate_now", false)
+	d.Set("eradicate_on_delete", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// getPgroupSnapshot returns a protection group snapshot, or nil if it does
+// not exist.
+// The pugo sdk does not support getting protection group snapshots.
+func getPgroupSnapshot(client *flasharray.Client, name string) (*flasharray.ProtectiongroupSnapshot, error) {
+	params := map[string]string{"snap": "true", "names": name}
+	req, err := client.NewRequest("GET", "pgroup", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := []flasharray.ProtectiongroupSnapshot{}
+	if _, err := client.Do(req, &snapshots, false); err != nil {
+		return nil, err
+	}
+	for _, s := range snapshots {
+		if s.Name == name {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+// listPgroupSnapshotVolumes returns the volume snapshots that are part of a
+// protection group snapshot.
+func listPgroupSnapshotVolumes(client *flasharray.Client, name string) ([]flasharray.Volume, error) {
+	params := map[string]string{"snap": "true", "pgrouplist": name}
+	return client.Volumes.ListVolumes(params)
+}
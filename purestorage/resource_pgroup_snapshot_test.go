@@ -0,0 +1,169 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureProtectiongroupSnapshotResourceName = "purefa_protectiongroup_snapshot.tfpgsnaptest"
+
+// Snapshot a protection group, and create a new volume from one of the volume snapshots
+func TestAccResourcePureProtectiongroupSnapshot_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureProtectiongroupSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureProtectiongroupSnapshotConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPureProtectiongroupSnapshotExists(testAccCheckPureProtectiongroupSnapshotResourceName, true),
+					resource.TestCheckResourceAttr(testAccCheckPureProtectiongroupSnapshotResourceName, "name", fmt.Sprintf("tfpgsnappgroup-%d.tfsnap", rInt)),
+					resource.TestCheckResourceAttr(testAccCheckPureProtectiongroupSnapshotResourceName, "volume_snapshots.%", "1"),
+					resource.TestCheckResourceAttr("purefa_volume.tfpgsnapclone", "source", fmt.Sprintf("tfpgsnappgroup-%d.tfsnap.tfpgsnapvolume-%d", rInt, rInt)),
+				),
+			},
+			{
+				ResourceName:            testAccCheckPureProtectiongroupSnapshotResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"apply_retention", "replicate_now", "eradicate_on_delete"},
+			},
+		},
+	})
+}
+
+// Snapshot a protection group, clone one of its volumes, import and eradicate
+// against the fake array.
+func TestResourcePureProtectiongroupSnapshot_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureProtectiongroupSnapshot()
+
+	if _, err := client.Volumes.CreateVolume("tfpgsnapvolume", 1048576); err != nil {
+		t.Fatalf("error creating volume: %s", err)
+	}
+	data := map[string]interface{}{"vollist": []string{"tfpgsnapvolume"}}
+	if _, err := client.Protectiongroups.CreateProtectiongroup("tfpgsnappgroup", data); err != nil {
+		t.Fatalf("error creating protection group: %s", err)
+	}
+
+	config := map[string]interface{}{"protection_group": "tfpgsnappgroup", "suffix": "tfsnap", "replicate_now": true}
+	testFakeApplyError(t, r, nil, config, client)
+
+	config = map[string]interface{}{
+		"protection_group":    "tfpgsnappgroup",
+		"suffix":              "tfsnap",
+		"apply_retention":     true,
+		"eradicate_on_delete": true,
+	}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "tfpgsnappgroup.tfsnap" || state.Attributes["volume_snapshots.tfpgsnapvolume"] != "tfpgsnappgroup.tfsnap.tfpgsnapvolume" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+	if snap := fa.pgroups["tfpgsnappgroup"].Snapshots[state.ID]; snap == nil || !snap.ApplyRetention || snap.ReplicateNow {
+		t.Fatalf("unexpected protection group snapshot: %#v", snap)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "apply_retention", "eradicate_on_delete")
+
+	clone := map[string]interface{}{"name": "tfpgsnapclone", "source": state.Attributes["volume_snapshots.tfpgsnapvolume"], "allow_destroy": true}
+	testFakeApply(t, resourcePureVolume(), nil, clone, client)
+	if v := fa.volumes["tfpgsnapclone"]; v == nil || v.Size != 1048576 {
+		t.Fatalf("volume was not copied from the snapshot: %#v", v)
+	}
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.pgroups["tfpgsnappgroup"].Snapshots[state.ID]; ok {
+		t.Fatalf("protection group snapshot was not eradicated")
+	}
+	if _, ok := fa.snapshots["tfpgsnappgroup.tfsnap.tfpgsnapvolume"]; ok {
+		t.Fatalf("volume snapshot was not eradicated")
+	}
+}
+
+func testAccCheckPureProtectiongroupSnapshotDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_protectiongroup_snapshot" {
+			continue
+		}
+
+		if snapshot, _ := getPgroupSnapshot(client, rs.Primary.ID); snapshot != nil {
+			return fmt.Errorf("protection group snapshot '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPureProtectiongroupSnapshotExists(n string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no ID is set")
+		}
+
+		client := testAccClient()
+		snapshot, err := getPgroupSnapshot(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if snapshot == nil && exists {
+			return fmt.Errorf("protection group snapshot does not exist: %s", n)
+		}
+		return nil
+	}
+}
+
+func testAccCheckPureProtectiongroupSnapshotConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_volume" "tfpgsnapvolume" {
+	name          = "tfpgsnapvolume-%d"
+	size          = 1024000000
+	allow_destroy = true
+}
+
+resource "purefa_protectiongroup" "tfpgsnappgroup" {
+	name    = "tfpgsnappgroup-%d"
+	volumes = [purefa_volume.tfpgsnapvolume.name]
+}
+
+resource "purefa_protectiongroup_snapshot" "tfpgsnaptest" {
+	protection_group    = purefa_protectiongroup.tfpgsnappgroup.name
+	suffix              = "tfsnap"
+	eradicate_on_delete = true
+}
+
+resource "purefa_volume" "tfpgsnapclone" {
+	name          = "tfpgsnapclone-%d"
+	source        = purefa_protectiongroup_snapshot.tfpgsnaptest.volume_snapshots[purefa_volume.tfpgsnapvolume.name]
+	allow_destroy = true
+}`, rInt, rInt, rInt)
+}
@@ -0,0 +1,111 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourcePureProtectiongroupTargetAllow allows a protection group of a
+// remote array to replicate to the array managed by the provider.
+func resourcePureProtectiongroupTargetAllow() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureProtectiongroupTargetAllowCreate,
+		ReadContext:   resourcePureProtectiongroupTargetAllowRead,
+		DeleteContext: resourcePureProtectiongroupTargetAllowDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"protection_group": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the replicated protection group on the target array, <source array>:<protection group>.",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^:]+:[^:]+$`), "must be <source array>:<protection group>"),
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the source array of the protection group.",
+			},
+		},
+	}
+}
+
+func resourcePureProtectiongroupTargetAllowCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	name := d.Get("protection_group").(string)
+	if _, err := client.Protectiongroups.SetProtectiongroup(name, map[string]bool{"allowed": true}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(name)
+	return resourcePureProtectiongroupTargetAllowRead(ctx, d, m)
+}
+
+func resourcePureProtectiongroupTargetAllowRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	p, _ := client.Protectiongroups.GetProtectiongroup(d.Id(), nil)
+	if p == nil {
+		d.SetId("")
+		return nil
+	}
+	array, err := client.Array.Get(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	allowed := false
+	for _, t := range flattenPgroupTargets(p.Targets) {
+		target := t.(map[string]interface{})
+		if target["name"] == array.ArrayName {
+			allowed = target["allowed"].(bool)
+		}
+	}
+	if !allowed {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("protection_group", p.Name)
+	d.Set("source", p.Source)
+	return nil
+}
+
+func resourcePureProtectiongroupTargetAllowDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	// The protection group is gone from the target array when the source
+	// array removed the target.
+	if p, _ := client.Protectiongroups.GetProtectiongroup(d.Id(), nil); p != nil {
+		if _, err := client.Protectiongroups.SetProtectiongroup(d.Id(), map[string]bool{"allowed": false}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
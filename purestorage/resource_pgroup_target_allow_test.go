@@ -0,0 +1,57 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"testing"
+)
+
+// Allow a protection group of a remote array to replicate to the fake array.
+func TestResourcePureProtectiongroupTargetAllow_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureProtectiongroupTargetAllow()
+
+	fa.pgroups["remotearray:tfpgrouptest"] = &fakePgroup{
+		Name:    "remotearray:tfpgrouptest",
+		Source:  "remotearray",
+		Targets: []map[string]interface{}{{"name": fa.ArrayName, "allowed": false}},
+	}
+	fa.pgroups["tfpgrouptest"] = &fakePgroup{Name: "tfpgrouptest", Source: fa.ArrayName}
+
+	// Replication is only allowed for protection groups of other arrays.
+	testFakeApplyError(t, r, nil, map[string]interface{}{"protection_group": "tfpgrouptest"}, client)
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{"protection_group": "remotearray:tfpgrouptest"}, client)
+	if state.ID != "remotearray:tfpgrouptest" || state.Attributes["source"] != "remotearray" || fa.pgroups[state.ID].Targets[0]["allowed"] != true {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	testFakeDestroy(t, r, state, client)
+	if fa.pgroups[state.ID].Targets[0]["allowed"] != false {
+		t.Fatalf("replication is still allowed")
+	}
+	if testFakeRefresh(t, r, state, client) != nil {
+		t.Fatalf("disallowed protection group was read")
+	}
+
+	// The protection group is gone when the source array removed the target.
+	state = testFakeApply(t, r, nil, map[string]interface{}{"protection_group": "remotearray:tfpgrouptest"}, client)
+	delete(fa.pgroups, state.ID)
+	testFakeDestroy(t, r, state, client)
+}
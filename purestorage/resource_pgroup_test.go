@@ -17,12 +17,13 @@
 package purestorage
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
 
-	"github.com/devans10/pugo/flasharray"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -269,8 +270,150 @@ func TestAccResourcePureProtectiongroup_update_withRetention(t *testing.T) {
 	})
 }
 
+// Create, update, import and destroy a protection group against the fake array.
+func TestResourcePureProtectiongroup_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureProtectiongroup()
+
+	for _, name := range []string{"tfpgroupvol1", "tfpgroupvol2"} {
+		if _, err := client.Volumes.CreateVolume(name, 1024000000); err != nil {
+			t.Fatalf("error creating volume: %s", err)
+		}
+	}
+
+	config := map[string]interface{}{
+		"name":    "tfpgrouptest",
+		"volumes": []interface{}{"tfpgroupvol1"},
+	}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "tfpgrouptest" || state.Attributes["source"] != fa.ArrayName {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	config["volumes"] = []interface{}{"tfpgroupvol1", "tfpgroupvol2"}
+	config["snap_enabled"] = true
+	config["snap_frequency"] = 7200
+	config["days"] = 14
+	state = testFakeApply(t, r, state, config, client)
+	pg := fa.pgroups["tfpgrouptest"]
+	if len(pg.Volumes) != 2 || pg.Schedule["snap_enabled"] != true || pg.Schedule["snap_frequency"] != 7200 || pg.Retention["days"] != 14 {
+		t.Fatalf("unexpected protection group after update: %#v", pg)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "eradicate_on_delete", "recover_if_destroyed")
+
+	testFakeDestroy(t, r, state, client)
+	if !fa.pgroups["tfpgrouptest"].Destroyed {
+		t.Fatalf("protection group was not destroyed")
+	}
+}
+
+// Add and remove replication targets of a protection group.
+func TestResourcePureProtectiongroup_targets(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.connectedArrays = []string{"fa2", "fa3"}
+	r := resourcePureProtectiongroup()
+
+	config := map[string]interface{}{
+		"name":   "tfpgrouptest",
+		"target": []interface{}{map[string]interface{}{"name": "fa2"}},
+	}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.Attributes["target.#"] != "1" || len(fa.pgroups["tfpgrouptest"].Targets) != 1 {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	// fa2 allows the replication, which is kept when fa3 is added.
+	fa.pgroups["tfpgrouptest"].Targets[0]["allowed"] = true
+	config["target"] = []interface{}{map[string]interface{}{"name": "fa2"}, map[string]interface{}{"name": "fa3"}}
+	state = testFakeApply(t, r, state, config, client)
+	if targets := fmt.Sprint(fa.pgroups["tfpgrouptest"].Targets); targets != "[map[allowed:true name:fa2] map[allowed:false name:fa3]]" {
+		t.Fatalf("unexpected targets: %s", targets)
+	}
+	d := r.Data(state)
+	for _, target := range d.Get("target").(*schema.Set).List() {
+		target := target.(map[string]interface{})
+		if target["allowed"] != (target["name"] == "fa2") {
+			t.Fatalf("unexpected target in state: %v", target)
+		}
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "eradicate_on_delete", "recover_if_destroyed")
+
+	config["target"] = []interface{}{map[string]interface{}{"name": "fa3"}}
+	testFakeApply(t, r, state, config, client)
+	if targets := fmt.Sprint(fa.pgroups["tfpgrouptest"].Targets); targets != "[map[allowed:false name:fa3]]" {
+		t.Fatalf("unexpected targets: %s", targets)
+	}
+
+	testFakeApplyError(t, r, nil, map[string]interface{}{
+		"name":   "tfpgrouptest2",
+		"target": []interface{}{map[string]interface{}{"name": "unconnected"}},
+	}, client)
+}
+
+// Move the targets of a version 0 state to the target blocks.
+func Test_resourcePureProtectiongroupStateUpgradeV0(t *testing.T) {
+	raw := map[string]interface{}{
+		"id":   "tfpgrouptest",
+		"name": "tfpgrouptest",
+		"targets": []interface{}{
+			map[string]interface{}{"name": "fa2", "allowed": "true"},
+			map[string]interface{}{"name": "fa3", "allowed": "false"},
+		},
+	}
+	state, err := resourcePureProtectiongroupStateUpgradeV0(context.Background(), raw, nil)
+	if err != nil {
+		t.Fatalf("error upgrading state: %s", err)
+	}
+	if _, ok := state["targets"]; ok {
+		t.Fatalf("targets was kept: %v", state)
+	}
+	if target := fmt.Sprint(state["target"]); target != "[map[allowed:true name:fa2] map[allowed:false name:fa3]]" {
+		t.Fatalf("unexpected target: %s", target)
+	}
+
+	state, err = resourcePureProtectiongroupStateUpgradeV0(context.Background(), map[string]interface{}{"id": "tfpgrouptest"}, nil)
+	if err != nil || fmt.Sprint(state["target"]) != "[]" {
+		t.Fatalf("unexpected state without targets: %v %v", state, err)
+	}
+
+	if v0 := resourcePureProtectiongroupV0(resourcePureProtectiongroup()); v0.Schema["targets"] == nil || v0.Schema["target"] != nil {
+		t.Fatalf("unexpected version 0 schema")
+	}
+}
+
+// Re-create a destroyed protection group by recovering it, then eradicate it.
+func TestResourcePureProtectiongroup_recover(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureProtectiongroup()
+
+	if _, err := client.Volumes.CreateVolume("tfpgroupvol1", 1024000000); err != nil {
+		t.Fatalf("error creating volume: %s", err)
+	}
+
+	config := map[string]interface{}{"name": "tfpgrouptest"}
+	state := testFakeApply(t, r, nil, config, client)
+	testFakeDestroy(t, r, state, client)
+
+	testFakeApplyError(t, r, nil, config, client)
+
+	config["volumes"] = []interface{}{"tfpgroupvol1"}
+	config["recover_if_destroyed"] = true
+	config["eradicate_on_delete"] = true
+	state = testFakeApply(t, r, nil, config, client)
+	if pg := fa.pgroups["tfpgrouptest"]; pg == nil || pg.Destroyed || len(pg.Volumes) != 1 {
+		t.Fatalf("protection group was not recovered: %#v", pg)
+	}
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.pgroups["tfpgrouptest"]; ok {
+		t.Fatalf("protection group was not eradicated")
+	}
+}
+
 func testAccCheckPureProtectiongroupDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*flasharray.Client)
+	client := testAccClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "purefa_protectiongroup" {
@@ -298,7 +441,7 @@ func testAccCheckPureProtectiongroupExists(n string, exists bool) resource.TestC
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name := rs.Primary.Attributes["name"]
 		_, err := client.Protectiongroups.GetProtectiongroup(name, nil)
 		if err != nil {
@@ -322,7 +465,7 @@ func testAccCheckPureProtectiongroupHosts(n string, host string, exists bool) re
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name := rs.Primary.Attributes["name"]
 		p, err := client.Protectiongroups.GetProtectiongroup(name, nil)
 		if err != nil {
@@ -352,7 +495,7 @@ func testAccCheckPureProtectiongroupHostgroups(n string, hostgroup string, exist
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name := rs.Primary.Attributes["name"]
 		p, err := client.Protectiongroups.GetProtectiongroup(name, nil)
 		if err != nil {
@@ -382,7 +525,7 @@ func testAccCheckPureProtectiongroupVolumes(n string, volume string, exists bool
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		name := rs.Primary.Attributes["name"]
 		p, err := client.Protectiongroups.GetProtectiongroup(name, nil)
 		if err != nil {
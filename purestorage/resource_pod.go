@@ -0,0 +1,290 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// purePod is the pod object returned by the array.
+// The pugo sdk Pod does not include the arrays the pod is stretched to.
+type purePod struct {
+	Name               string         `json:"name"`
+	Source             string         `json:"source"`
+	FailoverPreference []string       `json:"failover_preference"`
+	Arrays             []purePodArray `json:"arrays"`
+}
+
+type purePodArray struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func resourcePurePod() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePurePodCreate,
+		ReadContext:   resourcePurePodRead,
+		UpdateContext: resourcePurePodUpdate,
+		DeleteContext: resourcePurePodDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the pod.",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[\w\-\d]+$`), "can only contain letters, numbers and '-'"),
+			},
+			"arrays": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Arrays the pod is stretched to. Must include the array managed by the provider.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"failover_preference": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Arrays that are preferred to keep the pod online when the arrays lose contact with each other.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Source pod of a pod clone.",
+			},
+			"allow_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to false, this value will prevent the pod from being destroyed through Terraform.",
+			},
+			"eradicate_on_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, the pod is eradicated after it is destroyed instead of waiting for the eradication timer.",
+			},
+			"recover_if_destroyed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a destroyed pod with the same name is recovered instead of creating a new pod.",
+			},
+		},
+	}
+}
+
+func resourcePurePodCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if err := checkPodArrays(client, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A recovered pod is still stretched to the arrays it was stretched to
+	// when it was destroyed.
+	members := []string{}
+	var destroyed *flasharray.Pod
+	if d.Get("recover_if_destroyed").(bool) {
+		destroyed, _ = client.Pods.GetPod(d.Get("name").(string), map[string]string{"pending_only": "true"})
+	}
+	if destroyed != nil {
+		if _, err := client.Pods.RecoverPod(destroyed.Name); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(destroyed.Name)
+		pod, err := getPod(client, d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, a := range pod.Arrays {
+			members = append(members, a.Name)
+		}
+	} else {
+		pod, err := client.Pods.CreatePod(d.Get("name").(string), nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(pod.Name)
+	}
+
+	array, err := client.Array.Get(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	members = append(members, array.ArrayName)
+	for _, a := range d.Get("arrays").(*schema.Set).List() {
+		if stringInSlice(a.(string), members) {
+			continue
+		}
+		if _, err := client.Pods.ConnectPod(d.Id(), a.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if fp, ok := d.GetOk("failover_preference"); ok {
+		data := map[string]interface{}{"failover_preference": fp.([]interface{})}
+		if _, err := client.Pods.SetPod(d.Id(), data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePurePodRead(ctx, d, m)
+}
+
+func resourcePurePodRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	pod, _ := getPod(client, d.Id())
+	if pod == nil {
+		d.SetId("")
+		return nil
+	}
+
+	var arrays []string
+	for _, a := range pod.Arrays {
+		arrays = append(arrays, a.Name)
+	}
+	d.Set("name", pod.Name)
+	d.Set("arrays", arrays)
+	d.Set("failover_preference", pod.FailoverPreference)
+	d.Set("source", pod.Source)
+	return nil
+}
+
+func resourcePurePodUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if d.HasChange("name") {
+		pod, err := client.Pods.RenamePod(d.Id(), d.Get("name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(pod.Name)
+	}
+
+	var removed []interface{}
+	if d.HasChange("arrays") {
+		if err := checkPodArrays(client, d); err != nil {
+			return diag.FromErr(err)
+		}
+		o, n := d.GetChange("arrays")
+		for _, a := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+			if _, err := client.Pods.ConnectPod(d.Id(), a.(string)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		removed = o.(*schema.Set).Difference(n.(*schema.Set)).List()
+	}
+
+	// The failover preference is set after new arrays are connected and
+	// before old arrays are disconnected, as it may only reference arrays
+	// the pod is stretched to.
+	if d.HasChange("failover_preference") {
+		data := map[string]interface{}{"failover_preference": d.Get("failover_preference").([]interface{})}
+		if _, err := client.Pods.SetPod(d.Id(), data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, a := range removed {
+		if _, err := client.Pods.DisconnectPod(d.Id(), a.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePurePodRead(ctx, d, m)
+}
+
+// resourcePurePodDelete unstretches the pod from the other arrays, then
+// destroys it, and eradicates it if eradicate_on_delete is set.
+func resourcePurePodDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("allow_destroy") == false {
+		return diag.Errorf("The `allow_destroy` parameter is set to false. The pod can not be destroyed through Terraform.")
+	}
+
+	client := m.(*flasharray.Client)
+
+	pod, err := getPod(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	array, err := client.Array.Get(nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, a := range pod.Arrays {
+		if a.Name == array.ArrayName {
+			continue
+		}
+		if _, err := client.Pods.DisconnectPod(d.Id(), a.Name); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if _, err := client.Pods.DeletePod(d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("eradicate_on_delete").(bool) {
+		if _, err := client.Pods.EradicatePod(d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// getPod returns the pod including the arrays it is stretched to.
+func getPod(client *flasharray.Client, name string) (*purePod, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("pod/%s", name), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	pod := &purePod{}
+	if _, err := client.Do(req, pod, false); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// checkPodArrays verifies that the configured arrays include the array
+// managed by the provider, which can not unstretch the pod from itself.
+func checkPodArrays(client *flasharray.Client, d *schema.ResourceData) error {
+	arrays, ok := d.GetOk("arrays")
+	if !ok {
+		return nil
+	}
+	array, err := client.Array.Get(nil)
+	if err != nil {
+		return err
+	}
+	if !arrays.(*schema.Set).Contains(array.ArrayName) {
+		return fmt.Errorf("arrays must include %s, the array the pod is managed from", array.ArrayName)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPurePodResourceName = "purefa_pod.tfpodtest"
+
+// Create a pod, and put a volume in it
+func TestAccResourcePurePod_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPurePodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPurePodConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPurePodExists(testAccCheckPurePodResourceName, true),
+					resource.TestCheckResourceAttr(testAccCheckPurePodResourceName, "name", fmt.Sprintf("tfpodtest-%d", rInt)),
+					resource.TestCheckResourceAttr(testAccCheckPurePodResourceName, "arrays.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckPurePodConfigWithVolume(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPurePodExists(testAccCheckPurePodResourceName, true),
+					resource.TestCheckResourceAttr("purefa_volume.tfpodvolumetest", "full_name", fmt.Sprintf("tfpodtest-%d::tfpodvolumetest-%d", rInt, rInt)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourcePurePod_rename(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPurePodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPurePodConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPurePodExists(testAccCheckPurePodResourceName, true),
+				),
+			},
+			{
+				Config: testAccCheckPurePodConfigRename(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPurePodExists(testAccCheckPurePodResourceName, true),
+					resource.TestCheckResourceAttr(testAccCheckPurePodResourceName, "name", fmt.Sprintf("tfpodtest-rename-%d", rInt)),
+				),
+			},
+		},
+	})
+}
+
+// Create, stretch, import, unstretch and eradicate a pod against the fake array.
+func TestResourcePurePod_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.connectedArrays = []string{"remotearray"}
+	r := resourcePurePod()
+
+	config := map[string]interface{}{"name": "tfpodtest", "allow_destroy": true, "eradicate_on_delete": true}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "tfpodtest" || state.Attributes["arrays.#"] != "1" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	config["name"] = "tfpodtest-rename"
+	config["arrays"] = []interface{}{fa.ArrayName, "remotearray"}
+	config["failover_preference"] = []interface{}{"remotearray"}
+	state = testFakeApply(t, r, state, config, client)
+	pod := fa.pods["tfpodtest-rename"]
+	if pod == nil || len(pod.Arrays) != 2 || len(pod.FailoverPreference) != 1 {
+		t.Fatalf("unexpected pod after update: %#v", pod)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "allow_destroy", "eradicate_on_delete", "recover_if_destroyed")
+
+	config["arrays"] = []interface{}{"remotearray"}
+	testFakeApplyError(t, r, state, config, client)
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.pods["tfpodtest-rename"]; ok {
+		t.Fatalf("pod was not eradicated")
+	}
+}
+
+// Re-create a destroyed pod by recovering it.
+func TestResourcePurePod_recover(t *testing.T) {
+	fa, client := testFakeArray(t)
+	fa.connectedArrays = []string{"remotearray"}
+	r := resourcePurePod()
+
+	config := map[string]interface{}{"name": "tfpodtest", "allow_destroy": true}
+	state := testFakeApply(t, r, nil, config, client)
+	testFakeDestroy(t, r, state, client)
+
+	testFakeApplyError(t, r, nil, config, client)
+
+	config["recover_if_destroyed"] = true
+	config["arrays"] = []interface{}{fa.ArrayName, "remotearray"}
+	testFakeApply(t, r, nil, config, client)
+	if pod := fa.pods["tfpodtest"]; pod == nil || pod.Destroyed || len(pod.Arrays) != 2 {
+		t.Fatalf("pod was not recovered: %#v", pod)
+	}
+}
+
+func testAccCheckPurePodDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_pod" {
+			continue
+		}
+
+		_, err := client.Pods.GetPod(rs.Primary.ID, nil)
+		if err == nil {
+			return fmt.Errorf("pod '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPurePodExists(n string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no ID is set")
+		}
+
+		client := testAccClient()
+		_, err := client.Pods.GetPod(rs.Primary.ID, nil)
+		if err != nil {
+			if exists {
+				return fmt.Errorf("pod does not exist: %s", n)
+			}
+			return nil
+		}
+		return nil
+	}
+}
+
+func testAccCheckPurePodConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_pod" "tfpodtest" {
+	name                = "tfpodtest-%d"
+	allow_destroy       = true
+	eradicate_on_delete = true
+}`, rInt)
+}
+
+func testAccCheckPurePodConfigRename(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_pod" "tfpodtest" {
+	name                = "tfpodtest-rename-%d"
+	allow_destroy       = true
+	eradicate_on_delete = true
+}`, rInt)
+}
+
+func testAccCheckPurePodConfigWithVolume(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_pod" "tfpodtest" {
+	name                = "tfpodtest-%d"
+	allow_destroy       = true
+	eradicate_on_delete = true
+}
+
+resource "purefa_volume" "tfpodvolumetest" {
+	name          = "tfpodvolumetest-%d"
+	size          = 1024000000
+	pod           = purefa_pod.tfpodtest.name
+	allow_destroy = true
+}`, rInt, rInt)
+}
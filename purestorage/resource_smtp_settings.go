@@ -0,0 +1,112 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePureSMTPSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureSMTPSettingsCreateUpdate,
+		ReadContext:   resourcePureSMTPSettingsRead,
+		UpdateContext: resourcePureSMTPSettingsCreateUpdate,
+		DeleteContext: resourcePureSMTPSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"relay_host": {
+				Type:        schema.TypeString,
+				Description: "Hostname or IP address of the relay host, with an optional port. Alert emails are sent directly when it is empty.",
+				Optional:    true,
+				Default:     "",
+			},
+			"sender_domain": {
+				Type:        schema.TypeString,
+				Description: "Domain name of the sender of alert emails",
+				Optional:    true,
+				Default:     "",
+			},
+			"user_name": {
+				Type:        schema.TypeString,
+				Description: "User name to authenticate to the relay host with",
+				Optional:    true,
+				Default:     "",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Description: "Password to authenticate to the relay host with. The password can not be read back from the array.",
+				Optional:    true,
+				Sensitive:   true,
+				Default:     "",
+			},
+		},
+	}
+}
+
+func resourcePureSMTPSettingsCreateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	data := map[string]string{
+		"relay_host":    d.Get("relay_host").(string),
+		"sender_domain": d.Get("sender_domain").(string),
+		"user_name":     d.Get("user_name").(string),
+	}
+	if d.IsNewResource() || d.HasChange("password") {
+		data["password"] = d.Get("password").(string)
+	}
+
+	// The pugo sdk sets the smtp settings with POST, which the array does not support.
+	req, err := client.NewRequest("PUT", "smtp", nil, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := client.Do(req, &flasharray.SMTP{}, false); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("smtp-settings-%s", client.Target))
+
+	return resourcePureSMTPSettingsRead(ctx, d, m)
+}
+
+func resourcePureSMTPSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	smtp, err := client.SMTP.GetSMTP()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("relay_host", smtp.RelayHost)
+	d.Set("sender_domain", smtp.SenderDomain)
+	d.Set("user_name", smtp.Username)
+	return nil
+}
+
+// resourcePureSMTPSettingsDelete only removes the smtp settings from the
+// state. The settings on the array are left as they are.
+func resourcePureSMTPSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
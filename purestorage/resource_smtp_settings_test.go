@@ -0,0 +1,85 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccCheckPureSMTPSettingsResourceName = "purefa_smtp_settings.tfsmtpsettingstest"
+
+// Configure SMTP settings
+func TestAccResourcePureSMTPSettings_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureSMTPSettingsConfig("smtp.testdrive.local", "testdrive.local"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureSMTPSettingsResourceName, "relay_host", "smtp.testdrive.local"),
+					resource.TestCheckResourceAttr(testAccCheckPureSMTPSettingsResourceName, "sender_domain", "testdrive.local"),
+				),
+			},
+			{
+				Config: testAccCheckPureSMTPSettingsConfig("", "testdrive.local"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureSMTPSettingsResourceName, "relay_host", ""),
+				),
+			},
+		},
+	})
+}
+
+// Configure SMTP settings against the fake array.
+func TestResourcePureSMTPSettings_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureSMTPSettings()
+
+	config := map[string]interface{}{"relay_host": "smtp.testdrive.local:587", "sender_domain": "testdrive.local", "user_name": "pure", "password": "TfSMTPTest-1"}
+	state := testFakeApply(t, r, nil, config, client)
+	if fa.smtp["relay_host"] != "smtp.testdrive.local:587" || fa.smtp["password"] != "TfSMTPTest-1" {
+		t.Fatalf("unexpected smtp settings after create: %v", fa.smtp)
+	}
+
+	delete(config, "relay_host")
+	delete(config, "user_name")
+	delete(config, "password")
+	state = testFakeApply(t, r, state, config, client)
+	if fa.smtp["relay_host"] != "" || fa.smtp["user_name"] != "" || fa.smtp["password"] != "" || fa.smtp["sender_domain"] != "testdrive.local" {
+		t.Fatalf("unexpected smtp settings after update: %v", fa.smtp)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	// Deleting only removes the settings from the state.
+	testFakeDestroy(t, r, state, client)
+	if fa.smtp["sender_domain"] != "testdrive.local" {
+		t.Fatalf("smtp settings were changed on delete: %v", fa.smtp)
+	}
+}
+
+func testAccCheckPureSMTPSettingsConfig(relayHost string, senderDomain string) string {
+	return fmt.Sprintf(`
+resource "purefa_smtp_settings" "tfsmtpsettingstest" {
+	relay_host    = "%s"
+	sender_domain = "%s"
+}`, relayHost, senderDomain)
+}
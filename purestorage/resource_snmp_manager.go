@@ -0,0 +1,200 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePureSnmpManager() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureSnmpManagerCreate,
+		ReadContext:   resourcePureSnmpManagerRead,
+		UpdateContext: resourcePureSnmpManagerUpdate,
+		DeleteContext: resourcePureSnmpManagerDelete,
+		CustomizeDiff: resourcePureSnmpManagerCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the SNMP manager",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Description: "Hostname or IP address of the SNMP manager, with an optional port",
+				Required:    true,
+			},
+			"version": {
+				Type:         schema.TypeString,
+				Description:  "SNMP version, v2c or v3",
+				Optional:     true,
+				Default:      "v2c",
+				ValidateFunc: validation.StringInSlice([]string{"v2c", "v3"}, false),
+			},
+			"notification": {
+				Type:         schema.TypeString,
+				Description:  "Type of the notifications sent to the SNMP manager, trap or inform",
+				Optional:     true,
+				Default:      "trap",
+				ValidateFunc: validation.StringInSlice([]string{"trap", "inform"}, false),
+			},
+			"community": {
+				Type:        schema.TypeString,
+				Description: "SNMP v2c community",
+				Optional:    true,
+				Sensitive:   true,
+				Default:     "",
+			},
+			"user": {
+				Type:        schema.TypeString,
+				Description: "SNMP v3 user name",
+				Optional:    true,
+				Default:     "",
+			},
+			"auth_protocol": {
+				Type:         schema.TypeString,
+				Description:  "SNMP v3 authentication protocol, MD5 or SHA",
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringInSlice([]string{"", "MD5", "SHA"}, false),
+			},
+			"auth_passphrase": {
+				Type:        schema.TypeString,
+				Description: "SNMP v3 authentication passphrase",
+				Optional:    true,
+				Sensitive:   true,
+				Default:     "",
+			},
+			"privacy_protocol": {
+				Type:         schema.TypeString,
+				Description:  "SNMP v3 privacy protocol, AES or DES",
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringInSlice([]string{"", "AES", "DES"}, false),
+			},
+			"privacy_passphrase": {
+				Type:        schema.TypeString,
+				Description: "SNMP v3 privacy passphrase",
+				Optional:    true,
+				Sensitive:   true,
+				Default:     "",
+			},
+		},
+	}
+}
+
+// snmpManagerSecrets are the attributes the array does not return. They are
+// only sent when they change.
+var snmpManagerSecrets = []string{"community", "auth_passphrase", "privacy_passphrase"}
+
+var snmpManagerAttributes = []string{"host", "version", "notification", "user", "auth_protocol", "privacy_protocol"}
+
+func resourcePureSnmpManagerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	data := make(map[string]string)
+	for _, k := range append(snmpManagerAttributes, snmpManagerSecrets...) {
+		if v := d.Get(k).(string); v != "" {
+			data[k] = v
+		}
+	}
+
+	snmp, err := client.Snmp.CreateSnmp(d.Get("name").(string), data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(snmp.Name)
+
+	return resourcePureSnmpManagerRead(ctx, d, m)
+}
+
+func resourcePureSnmpManagerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	snmp, _ := client.Snmp.GetSnmp(d.Id())
+	if snmp == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", snmp.Name)
+	d.Set("host", snmp.Host)
+	d.Set("version", snmp.Version)
+	d.Set("notification", snmp.Notification)
+	d.Set("user", snmp.User)
+	d.Set("auth_protocol", snmp.AuthProtocol)
+	d.Set("privacy_protocol", snmp.PrivacyProtocol)
+	return nil
+}
+
+func resourcePureSnmpManagerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	data := make(map[string]string)
+	for _, k := range append(snmpManagerAttributes, snmpManagerSecrets...) {
+		if d.HasChange(k) {
+			data[k] = d.Get(k).(string)
+		}
+	}
+
+	if len(data) > 0 {
+		if _, err := client.Snmp.SetSnmp(d.Id(), data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePureSnmpManagerRead(ctx, d, m)
+}
+
+func resourcePureSnmpManagerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Snmp.DeleteSnmp(d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourcePureSnmpManagerCustomizeDiff checks that the settings of the
+// configured SNMP version are set.
+func resourcePureSnmpManagerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for _, k := range []string{"version", "user", "auth_protocol", "privacy_protocol"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	if d.Get("version").(string) == "v3" && d.Get("user").(string) == "" {
+		return fmt.Errorf("user is required for SNMP v3")
+	}
+	if d.Get("privacy_protocol").(string) != "" && d.Get("auth_protocol").(string) == "" {
+		return fmt.Errorf("auth_protocol is required when privacy_protocol is set")
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureSnmpManagerResourceName = "purefa_snmp_manager.tfsnmpmanagertest"
+
+// Create a v2c SNMP manager, and change it to v3
+func TestAccResourcePureSnmpManager_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureSnmpManagerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureSnmpManagerConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureSnmpManagerResourceName, "host", "snmp.testdrive.local"),
+					resource.TestCheckResourceAttr(testAccCheckPureSnmpManagerResourceName, "version", "v2c"),
+					resource.TestCheckResourceAttr(testAccCheckPureSnmpManagerResourceName, "notification", "trap"),
+				),
+			},
+			{
+				Config: testAccCheckPureSnmpManagerConfigV3(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureSnmpManagerResourceName, "version", "v3"),
+					resource.TestCheckResourceAttr(testAccCheckPureSnmpManagerResourceName, "user", "tfsnmpuser"),
+					resource.TestCheckResourceAttr(testAccCheckPureSnmpManagerResourceName, "auth_protocol", "SHA"),
+					resource.TestCheckResourceAttr(testAccCheckPureSnmpManagerResourceName, "privacy_protocol", "AES"),
+				),
+			},
+			{
+				ResourceName:            testAccCheckPureSnmpManagerResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"community", "auth_passphrase", "privacy_passphrase"},
+			},
+		},
+	})
+}
+
+// Create, change to v3 and delete an SNMP manager against the fake array.
+func TestResourcePureSnmpManager_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureSnmpManager()
+
+	config := map[string]interface{}{"name": "tfsnmpmanagertest", "host": "snmp.testdrive.local", "community": "tfcommunity"}
+	state := testFakeApply(t, r, nil, config, client)
+	if s := fa.snmpManagers["tfsnmpmanagertest"]; s == nil || s.Version != "v2c" || s.Community != "tfcommunity" {
+		t.Fatalf("unexpected SNMP manager after create: %#v", s)
+	}
+
+	config["version"] = "v3"
+	testFakeApplyError(t, r, state, config, client)
+
+	config["user"] = "tfsnmpuser"
+	config["privacy_protocol"] = "AES"
+	config["privacy_passphrase"] = "TfPrivacyTest-1"
+	testFakeApplyError(t, r, state, config, client)
+
+	config["auth_protocol"] = "SHA"
+	config["auth_passphrase"] = "TfAuthTest-1"
+	config["notification"] = "inform"
+	state = testFakeApply(t, r, state, config, client)
+	s := fa.snmpManagers["tfsnmpmanagertest"]
+	if s.Version != "v3" || s.User != "tfsnmpuser" || s.AuthPassphrase != "TfAuthTest-1" || s.PrivacyPassphrase != "TfPrivacyTest-1" || s.Notification != "inform" {
+		t.Fatalf("unexpected SNMP manager after update: %#v", s)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "community", "auth_passphrase", "privacy_passphrase")
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.snmpManagers["tfsnmpmanagertest"]; ok {
+		t.Fatalf("SNMP manager was not deleted")
+	}
+}
+
+func testAccCheckPureSnmpManagerDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_snmp_manager" {
+			continue
+		}
+
+		_, err := client.Snmp.GetSnmp(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("SNMP manager '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPureSnmpManagerConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_snmp_manager" "tfsnmpmanagertest" {
+	name      = "tfsnmpmanagertest-%d"
+	host      = "snmp.testdrive.local"
+	community = "tfcommunity"
+}`, rInt)
+}
+
+func testAccCheckPureSnmpManagerConfigV3(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_snmp_manager" "tfsnmpmanagertest" {
+	name               = "tfsnmpmanagertest-%d"
+	host               = "snmp.testdrive.local"
+	version            = "v3"
+	user               = "tfsnmpuser"
+	auth_protocol      = "SHA"
+	auth_passphrase    = "TfAuthTest-1"
+	privacy_protocol   = "AES"
+	privacy_passphrase = "TfPrivacyTest-1"
+}`, rInt)
+}
@@ -0,0 +1,177 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePureSubnet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureSubnetCreate,
+		ReadContext:   resourcePureSubnetRead,
+		UpdateContext: resourcePureSubnetUpdate,
+		DeleteContext: resourcePureSubnetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the subnet.",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[\w\-\d]+$`), "can only contain letters, numbers and '-'"),
+			},
+			"prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Routing prefix of the subnet, as an IPv4 CIDR network (ex. 192.168.10.0/24).",
+				ValidateFunc: validation.IsCIDRNetwork(0, 32),
+			},
+			"vlan": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "VLAN ID of the subnet. 0 means the subnet is not tagged.",
+				ValidateFunc: validation.IntBetween(0, 4094),
+			},
+			"gateway": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Gateway of the subnet.",
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"mtu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1500,
+				Description:  "MTU of the subnet.",
+				ValidateFunc: validation.IntBetween(568, 9000),
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable/disable the subnet.",
+			},
+			"services": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Services of the interfaces in the subnet.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourcePureSubnetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	subnet, err := client.Networks.CreateSubnet(d.Get("name").(string), d.Get("prefix").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(subnet.Name)
+
+	data := map[string]interface{}{
+		"vlan":    d.Get("vlan").(int),
+		"mtu":     d.Get("mtu").(int),
+		"enabled": d.Get("enabled").(bool),
+	}
+	if gateway, ok := d.GetOk("gateway"); ok {
+		data["gateway"] = gateway.(string)
+	}
+	if _, err := client.Networks.SetSubnet(d.Id(), data); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePureSubnetRead(ctx, d, m)
+}
+
+func resourcePureSubnetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	subnet, _ := client.Networks.GetSubnet(d.Id())
+	if subnet == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", subnet.Name)
+	d.Set("prefix", subnet.Prefix)
+	d.Set("vlan", subnet.Vlan)
+	d.Set("gateway", subnet.Gateway)
+	d.Set("mtu", subnet.Mtu)
+	d.Set("enabled", subnet.Enabled)
+	d.Set("services", subnet.Services)
+	return nil
+}
+
+func resourcePureSubnetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if d.HasChange("name") {
+		subnet, err := client.Networks.RenameSubnet(d.Id(), d.Get("name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(subnet.Name)
+	}
+
+	data := make(map[string]interface{})
+	for _, k := range []string{"prefix", "vlan", "gateway", "mtu"} {
+		if d.HasChange(k) {
+			data[k] = d.Get(k)
+		}
+	}
+	if len(data) > 0 {
+		if _, err := client.Networks.SetSubnet(d.Id(), data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("enabled") {
+		var err error
+		if d.Get("enabled").(bool) {
+			_, err = client.Networks.EnableSubnet(d.Id())
+		} else {
+			_, err = client.Networks.DisableSubnet(d.Id())
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePureSubnetRead(ctx, d, m)
+}
+
+func resourcePureSubnetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Networks.DeleteSubnet(d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
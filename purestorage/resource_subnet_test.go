@@ -0,0 +1,146 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureSubnetResourceName = "purefa_subnet.tfsubnettest"
+
+// Create a subnet, and change its gateway and mtu
+func TestAccResourcePureSubnet_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureSubnetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureSubnetConfig(rInt, "192.168.100.1", 1500),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPureSubnetExists(testAccCheckPureSubnetResourceName, true),
+					resource.TestCheckResourceAttr(testAccCheckPureSubnetResourceName, "prefix", "192.168.100.0/24"),
+					resource.TestCheckResourceAttr(testAccCheckPureSubnetResourceName, "vlan", "100"),
+					resource.TestCheckResourceAttr(testAccCheckPureSubnetResourceName, "gateway", "192.168.100.1"),
+				),
+			},
+			{
+				Config: testAccCheckPureSubnetConfig(rInt, "192.168.100.254", 9000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPureSubnetExists(testAccCheckPureSubnetResourceName, true),
+					resource.TestCheckResourceAttr(testAccCheckPureSubnetResourceName, "gateway", "192.168.100.254"),
+					resource.TestCheckResourceAttr(testAccCheckPureSubnetResourceName, "mtu", "9000"),
+				),
+			},
+			{
+				ResourceName:      testAccCheckPureSubnetResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Create, rename, disable and delete a subnet against the fake array.
+func TestResourcePureSubnet_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureSubnet()
+
+	config := map[string]interface{}{"name": "tfsubnettest", "prefix": "192.168.100.0/24", "vlan": 100, "gateway": "192.168.100.1"}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "tfsubnettest" || state.Attributes["mtu"] != "1500" || state.Attributes["enabled"] != "true" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	config["name"] = "tfsubnettest-rename"
+	config["mtu"] = 9000
+	config["enabled"] = false
+	delete(config, "gateway")
+	state = testFakeApply(t, r, state, config, client)
+	s := fa.subnets["tfsubnettest-rename"]
+	if s == nil || s.Mtu != 9000 || s.Enabled || s.Gateway != "" {
+		t.Fatalf("unexpected subnet after update: %#v", s)
+	}
+
+	config["gateway"] = "192.168.200.1"
+	testFakeApplyError(t, r, state, config, client)
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.subnets["tfsubnettest-rename"]; ok {
+		t.Fatalf("subnet was not deleted")
+	}
+}
+
+func testAccCheckPureSubnetDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_subnet" {
+			continue
+		}
+
+		_, err := client.Networks.GetSubnet(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("subnet '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPureSubnetExists(n string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no ID is set")
+		}
+
+		client := testAccClient()
+		_, err := client.Networks.GetSubnet(rs.Primary.ID)
+		if err != nil {
+			if exists {
+				return fmt.Errorf("subnet does not exist: %s", n)
+			}
+			return nil
+		}
+		return nil
+	}
+}
+
+func testAccCheckPureSubnetConfig(rInt int, gateway string, mtu int) string {
+	return fmt.Sprintf(`
+resource "purefa_subnet" "tfsubnettest" {
+	name    = "tfsubnettest-%d"
+	prefix  = "192.168.100.0/24"
+	vlan    = 100
+	gateway = "%s"
+	mtu     = %d
+}`, rInt, gateway, mtu)
+}
@@ -18,6 +18,7 @@ package purestorage
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 
 	"github.com/devans10/pugo/flasharray"
@@ -35,20 +36,47 @@ func resourcePureVolumegroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Schema: map[string]*schema.Schema{
+		Schema: qosSchema(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
 				Description:  "Name of the volume group",
 				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[\w\-\d]+$`), "can only contain letters, numbers and '-'"),
 			},
-		},
+			"eradicate_on_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, the volume group is eradicated after it is destroyed instead of waiting for the eradication timer.",
+			},
+			"recover_if_destroyed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a destroyed volume group with the same name is recovered instead of creating a new volume group.",
+			},
+		}),
 	}
 }
 
 func resourcePureVolumegroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*flasharray.Client)
 
+	if d.Get("recover_if_destroyed").(bool) {
+		if destroyed, _ := getDestroyedVgroup(client, d.Get("name").(string)); destroyed != nil {
+			if _, err := client.Vgroups.RecoverVgroup(destroyed.Name); err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(destroyed.Name)
+			// The recovered volume group keeps its limits, which are
+			// replaced by the configured ones.
+			if _, err := client.Vgroups.SetVgroup(destroyed.Name, qosData(d, true)); err != nil {
+				return diag.FromErr(err)
+			}
+			return resourcePureVolumegroupRead(ctx, d, m)
+		}
+	}
+
 	if vgroup, err := client.Vgroups.CreateVgroup(d.Get("name").(string)); err != nil {
 		return diag.FromErr(err)
 	} else {
@@ -56,7 +84,13 @@ func resourcePureVolumegroupCreate(ctx context.Context, d *schema.ResourceData,
 		d.SetId(vgroup.Name)
 	}
 
-	return nil
+	if data := qosData(d, false); len(data) > 0 {
+		if _, err := client.Vgroups.SetVgroup(d.Id(), data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePureVolumegroupRead(ctx, d, m)
 }
 
 func resourcePureVolumegroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -70,6 +104,12 @@ func resourcePureVolumegroupRead(ctx context.Context, d *schema.ResourceData, m
 		d.SetId(vgroup.Name)
 	}
 
+	qos, err := getQos(client, "vgroup", d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setQos(d, qos)
+
 	return nil
 }
 
@@ -88,6 +128,12 @@ func resourcePureVolumegroupUpdate(ctx context.Context, d *schema.ResourceData,
 
 	}
 
+	if data := qosData(d, false); len(data) > 0 {
+		if _, err := client.Vgroups.SetVgroup(d.Id(), data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourcePureVolumegroupRead(ctx, d, m)
 }
 
@@ -100,6 +146,27 @@ func resourcePureVolumegroupDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if d.Get("eradicate_on_delete").(bool) {
+		if _, err := client.Vgroups.EradicateVgroup(d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
+
+// getDestroyedVgroup returns a volume group that is pending eradication.
+// The pugo sdk does not support parameters when getting a volume group.
+func getDestroyedVgroup(client *flasharray.Client, name string) (*flasharray.Vgroup, error) {
+	params := map[string]string{"pending_only": "true"}
+	req, err := client.NewRequest("GET", fmt.Sprintf("vgroup/%s", name), params, nil)
+	if err != nil {
+		return nil, err
+	}
+	vgroup := &flasharray.Vgroup{}
+	if _, err := client.Do(req, vgroup, false); err != nil {
+		return nil, err
+	}
+	return vgroup, nil
+}
@@ -22,7 +22,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/devans10/pugo/flasharray"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -111,8 +110,80 @@ func TestAccResourcePureVolumeGroup_withVolumes(t *testing.T) {
 	})
 }
 
+// Create, rename, import and destroy a volume group against the fake array.
+func TestResourcePureVolumeGroup_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVolumegroup()
+
+	state := testFakeApply(t, r, nil, map[string]interface{}{"name": "tfvgrouptest"}, client)
+	if state.ID != "tfvgrouptest" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+	if _, err := client.Volumes.CreateVolume("tfvgrouptest/tfvgroupvol", 1024000000); err != nil {
+		t.Fatalf("error creating volume: %s", err)
+	}
+
+	state = testFakeApply(t, r, state, map[string]interface{}{"name": "tfvgrouptest-rename"}, client)
+	if _, ok := fa.volumes["tfvgrouptest-rename/tfvgroupvol"]; !ok {
+		t.Fatalf("volume was not moved with the renamed volume group")
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "eradicate_on_delete", "recover_if_destroyed")
+
+	if _, err := client.Volumes.DeleteVolume("tfvgrouptest-rename/tfvgroupvol"); err != nil {
+		t.Fatalf("error destroying volume: %s", err)
+	}
+	testFakeDestroy(t, r, state, client)
+	if !fa.vgroups["tfvgrouptest-rename"].Destroyed {
+		t.Fatalf("volume group was not destroyed")
+	}
+}
+
+// Set and remove the QoS limits of a volume group.
+func TestResourcePureVolumeGroup_qos(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVolumegroup()
+
+	config := map[string]interface{}{"name": "tfvgrouptest", "bandwidth_limit": "2G"}
+	state := testFakeApply(t, r, nil, config, client)
+	if vg := fa.vgroups["tfvgrouptest"]; vg.BandwidthLimit != 2*1024*1024*1024 || vg.IopsLimit != 0 {
+		t.Fatalf("unexpected limits after create: %#v", vg.fakeQos)
+	}
+
+	delete(config, "bandwidth_limit")
+	config["iops_limit"] = "1M"
+	testFakeApply(t, r, state, config, client)
+	if vg := fa.vgroups["tfvgrouptest"]; vg.BandwidthLimit != 0 || vg.IopsLimit != 1000000 {
+		t.Fatalf("unexpected limits after update: %#v", vg.fakeQos)
+	}
+}
+
+// Re-create a destroyed volume group by recovering it, then eradicate it.
+func TestResourcePureVolumeGroup_recover(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVolumegroup()
+
+	config := map[string]interface{}{"name": "tfvgrouptest"}
+	state := testFakeApply(t, r, nil, config, client)
+	testFakeDestroy(t, r, state, client)
+
+	testFakeApplyError(t, r, nil, config, client)
+
+	config["recover_if_destroyed"] = true
+	config["eradicate_on_delete"] = true
+	state = testFakeApply(t, r, nil, config, client)
+	if vg := fa.vgroups["tfvgrouptest"]; vg == nil || vg.Destroyed {
+		t.Fatalf("volume group was not recovered: %#v", vg)
+	}
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.vgroups["tfvgrouptest"]; ok {
+		t.Fatalf("volume group was not eradicated")
+	}
+}
+
 func testAccCheckPureVolumeGroupDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*flasharray.Client)
+	client := testAccClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "purefa_volumegroup" {
@@ -141,7 +212,7 @@ type Vgroup struct {
 // Checks if resources are still pending for deletion and eredicates if needed
 // The pugo sdk does not support listing deleted volumegroups. This method includes a temporary fix.
 func testAccCheckPureVolumeGroupEradicate(s *terraform.State) error {
-	client := testAccProvider.Meta().(*flasharray.Client)
+	client := testAccClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "purefa_volumegroup" {
@@ -176,7 +247,7 @@ func testAccCheckPureVolumeGroupExists(n string, exists bool) resource.TestCheck
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		_, err := client.Vgroups.GetVgroup(rs.Primary.ID)
 		if err != nil {
 			if exists {
@@ -204,7 +275,7 @@ func testAccCheckPureVolumeGroupCount(testID string, count int) resource.TestChe
 			}
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		if vgroups, err := client.Vgroups.ListVgroups(); err == nil {
 			for _, vgroup := range vgroups {
 				if strings.Contains(vgroup.Name, testID) {
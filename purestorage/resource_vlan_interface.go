@@ -0,0 +1,154 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePureVlanInterface() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureVlanInterfaceCreate,
+		ReadContext:   resourcePureVlanInterfaceRead,
+		UpdateContext: resourcePureVlanInterfaceUpdate,
+		DeleteContext: resourcePureVlanInterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the VLAN interface, the physical interface followed by the VLAN ID of the subnet (ex. ct0.eth2.100).",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[\w\-]+(\.[\w\-]+)*\.\d+$`), "must be the name of an interface followed by a VLAN ID"),
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the subnet of the VLAN interface. The subnet must have a VLAN ID.",
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "IPv4 address of the VLAN interface.",
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable/disable the VLAN interface.",
+			},
+			"netmask": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Netmask of the VLAN interface, set by the subnet.",
+			},
+			"gateway": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Gateway of the VLAN interface, set by the subnet.",
+			},
+			"mtu": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "MTU of the VLAN interface, set by the subnet.",
+			},
+			"services": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Services of the VLAN interface, inherited from the physical interface.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourcePureVlanInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	iface, err := client.Networks.CreateVlanInterface(d.Get("name").(string), d.Get("subnet").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(iface.Name)
+
+	data := map[string]interface{}{"enabled": d.Get("enabled").(bool)}
+	if address, ok := d.GetOk("address"); ok {
+		data["address"] = address.(string)
+	}
+	if _, err := client.Networks.SetNetworkInterface(d.Id(), data); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePureVlanInterfaceRead(ctx, d, m)
+}
+
+func resourcePureVlanInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	iface, _ := client.Networks.GetNetworkInterface(d.Id())
+	if iface == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", iface.Name)
+	d.Set("subnet", iface.Subnet)
+	d.Set("address", iface.Address)
+	d.Set("enabled", iface.Enabled)
+	d.Set("netmask", iface.Netmask)
+	d.Set("gateway", iface.Gateway)
+	d.Set("mtu", iface.Mtu)
+	d.Set("services", iface.Services)
+	return nil
+}
+
+func resourcePureVlanInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if d.HasChanges("address", "enabled") {
+		data := map[string]interface{}{
+			"address": d.Get("address").(string),
+			"enabled": d.Get("enabled").(bool),
+		}
+		if _, err := client.Networks.SetNetworkInterface(d.Id(), data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePureVlanInterfaceRead(ctx, d, m)
+}
+
+func resourcePureVlanInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Networks.DeleteVlanInterface(d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureVlanInterfaceResourceName = "purefa_vlan_interface.tfvlaninterfacetest"
+
+// Create a VLAN interface on ct0.eth2, and change its address
+func TestAccResourcePureVlanInterface_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureVlanInterfaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureVlanInterfaceConfig(rInt, "192.168.100.10"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureVlanInterfaceResourceName, "name", "ct0.eth2.100"),
+					resource.TestCheckResourceAttr(testAccCheckPureVlanInterfaceResourceName, "address", "192.168.100.10"),
+					resource.TestCheckResourceAttr(testAccCheckPureVlanInterfaceResourceName, "netmask", "255.255.255.0"),
+				),
+			},
+			{
+				Config: testAccCheckPureVlanInterfaceConfig(rInt, "192.168.100.11"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCheckPureVlanInterfaceResourceName, "address", "192.168.100.11"),
+				),
+			},
+			{
+				ResourceName:      testAccCheckPureVlanInterfaceResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Create, disable and delete a VLAN interface against the fake array.
+func TestResourcePureVlanInterface_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVlanInterface()
+
+	if _, err := client.Networks.CreateSubnet("tfsubnettest", "192.168.100.0/24"); err != nil {
+		t.Fatalf("error creating subnet: %s", err)
+	}
+
+	config := map[string]interface{}{"name": "ct0.eth2.100", "subnet": "tfsubnettest", "address": "192.168.100.10"}
+	testFakeApplyError(t, r, nil, config, client)
+
+	if _, err := client.Networks.SetSubnet("tfsubnettest", map[string]interface{}{"vlan": 100, "mtu": 9000}); err != nil {
+		t.Fatalf("error setting subnet VLAN: %s", err)
+	}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.Attributes["netmask"] != "255.255.255.0" || state.Attributes["mtu"] != "9000" || state.Attributes["services.0"] != "iscsi" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	config["enabled"] = false
+	delete(config, "address")
+	state = testFakeApply(t, r, state, config, client)
+	if iface := fa.interfaces["ct0.eth2.100"]; iface.Enabled || iface.Address != "" {
+		t.Fatalf("unexpected interface after update: %#v", iface)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client)
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.interfaces["ct0.eth2.100"]; ok {
+		t.Fatalf("VLAN interface was not deleted")
+	}
+}
+
+func testAccCheckPureVlanInterfaceDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_vlan_interface" {
+			continue
+		}
+
+		_, err := client.Networks.GetNetworkInterface(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("VLAN interface '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPureVlanInterfaceConfig(rInt int, address string) string {
+	return fmt.Sprintf(`
+resource "purefa_subnet" "tfsubnettest" {
+	name   = "tfsubnettest-%d"
+	prefix = "192.168.100.0/24"
+	vlan   = 100
+}
+
+resource "purefa_vlan_interface" "tfvlaninterfacetest" {
+	name    = "ct0.eth2.${purefa_subnet.tfsubnettest.vlan}"
+	subnet  = purefa_subnet.tfsubnettest.name
+	address = "%s"
+}`, rInt, address)
+}
@@ -18,9 +18,11 @@ package purestorage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/devans10/pugo/flasharray"
@@ -30,21 +32,35 @@ import (
 )
 
 func resourcePureVolume() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourcePureVolumeCreate,
 		ReadContext:   resourcePureVolumeRead,
 		UpdateContext: resourcePureVolumeUpdate,
 		DeleteContext: resourcePureVolumeDelete,
+		CustomizeDiff: resourcePureVolumeCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourcePureVolumeImport,
 		},
-		Schema: map[string]*schema.Schema{
+		SchemaVersion: 1,
+		Schema: qosSchema(map[string]*schema.Schema{
 			"allow_destroy": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 				Description: "When set to false, this value will prevent  volume from being destroyed through Terraform.",
 			},
+			"eradicate_on_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, the volume is eradicated after it is destroyed instead of waiting for the eradication timer.",
+			},
+			"recover_if_destroyed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a destroyed volume with the same name is recovered instead of creating a new volume.",
+			},
 			"name": {
 				Description:  "Name of the volume.",
 				Type:         schema.TypeString,
@@ -57,10 +73,22 @@ func resourcePureVolume() *schema.Resource {
 				Computed:    true,
 			},
 			"size": {
-				Description: "Specified provisioned size of the volume. Number in bytes",
+				Description:      "Specified provisioned size of the volume. Number in bytes, with an optional K, M, G, T or P suffix in multiples of 1024, e.g. 500G or 1.5TiB",
+				Type:             schema.TypeString,
+				Required:         false, //can't be required because when source is set, the size is not required.
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validateVolumeSize,
+				DiffSuppressFunc: suppressEquivalentSizes,
+			},
+			"size_bytes": {
+				Description: "Provisioned size of the volume in bytes.",
 				Type:        schema.TypeInt,
-				Required:    false, //can't be required because when source is set, the size is not required.
-				Optional:    true,
+				Computed:    true,
+			},
+			"size_human": {
+				Description: "Provisioned size of the volume with the largest unit, e.g. 1.5T.",
+				Type:        schema.TypeString,
 				Computed:    true,
 			},
 			"source": {
@@ -83,14 +111,80 @@ func resourcePureVolume() *schema.Resource {
 				Computed:    true,
 			},
 			"volume_group": {
-				Description: "Name of the volume group the volume will be part of",
-				Type:        schema.TypeString,
-				Required:    false,
+				Description:   "Name of the volume group the volume will be part of",
+				Type:          schema.TypeString,
+				Required:      false,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"pod"},
+			},
+			"pod": {
+				Description:   "Name of the pod the volume will be part of",
+				Type:          schema.TypeString,
+				Required:      false,
+				Optional:      true,
+				ConflictsWith: []string{"volume_group"},
+			},
+			"protection_group": {
+				Description: "Names of the protection groups the volume is a member of. The membership is not managed when not set.",
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Computed:    true,
 			},
+		}),
+	}
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourcePureVolumeV0(r).CoreConfigSchema().ImpliedType(),
+			Upgrade: resourcePureVolumeStateUpgradeV0,
 		},
 	}
+	return r
+}
+
+// resourcePureVolumeV0 returns the version 0 of the volume, with the size in
+// bytes.
+func resourcePureVolumeV0(r *schema.Resource) *schema.Resource {
+	s := make(map[string]*schema.Schema, len(r.Schema))
+	for k, v := range r.Schema {
+		s[k] = v
+	}
+	s["size"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		Computed: true,
+	}
+	delete(s, "size_bytes")
+	delete(s, "size_human")
+	return &schema.Resource{Schema: s}
+}
+
+// resourcePureVolumeStateUpgradeV0 converts the size in bytes of a version 0
+// volume to a string, and sets the size attributes from it.
+func resourcePureVolumeStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	var size int64
+	switch v := rawState["size"].(type) {
+	case nil:
+		return rawState, nil
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("error upgrading the size of the volume: %s", err)
+		}
+		size = n
+	case float64:
+		size = int64(v)
+	case int:
+		size = int64(v)
+	default:
+		return nil, fmt.Errorf("error upgrading the size of the volume: unexpected size %v", v)
+	}
+	rawState["size"] = strconv.FormatInt(size, 10)
+	rawState["size_bytes"] = size
+	rawState["size_human"] = formatSize(int(size))
+	return rawState, nil
 }
 
 // resourcePureVolumeCreate creates a Pure Volume on a FlashArray according
@@ -105,19 +199,19 @@ func resourcePureVolumeCreate(ctx context.Context, d *schema.ResourceData, m int
 	var v *flasharray.Volume
 	var err error
 
-	name, _ := d.GetOk("name")
-	vgroup, vgroupOk := d.GetOk("volume_group")
-	var fullName string
-	if vgroupOk {
-		fullName = fmt.Sprintf("%s/%s", vgroup, name)
-	} else {
-		fullName = name.(string)
+	fullName := volumeFullName(d.Get("pod"), d.Get("volume_group"), d.Get("name"))
+
+	if d.Get("recover_if_destroyed").(bool) {
+		if destroyed, _ := client.Volumes.GetVolume(fullName, map[string]string{"pending_only": "true"}); destroyed != nil {
+			return resourcePureVolumeRecover(ctx, d, m, destroyed)
+		}
 	}
 
 	s, s_ok := d.GetOk("source")
 	if !s_ok || s.(string) == "" {
-		z, _ := d.GetOk("size")
-		if v, err = client.Volumes.CreateVolume(fullName, z.(int)); err != nil {
+		// The size is validated, so it parses.
+		z, _ := parseSize(d.Get("size").(string))
+		if v, err = client.Volumes.CreateVolume(fullName, z); err != nil {
 			return diag.FromErr(err)
 		}
 	} else {
@@ -127,6 +221,53 @@ func resourcePureVolumeCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	d.SetId(v.Name)
+	if _, ok := d.GetOk("protection_group"); ok {
+		if err := setVolumeProtectionGroups(client, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if data := qosData(d, false); len(data) > 0 {
+		if _, err := client.Volumes.SetVolume(v.Name, data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourcePureVolumeRead(ctx, d, m)
+}
+
+// resourcePureVolumeRecover recovers a destroyed volume in place of creating
+// a new one. A configured source is copied over the recovered volume, and a
+// configured size larger than the recovered volume extends it.
+func resourcePureVolumeRecover(ctx context.Context, d *schema.ResourceData, m interface{}, v *flasharray.Volume) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Volumes.RecoverVolume(v.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(v.Name)
+	log.Printf("[INFO] Recovered destroyed volume %s.", v.Name)
+
+	if s, ok := d.GetOk("source"); ok && s.(string) != "" {
+		if _, err := client.Volumes.CopyVolume(v.Name, s.(string), true); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if z, err := parseSize(d.Get("size").(string)); err == nil && z > v.Size {
+		if _, err := client.Volumes.ExtendVolume(v.Name, z); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if _, ok := d.GetOk("protection_group"); ok {
+		if err := setVolumeProtectionGroups(client, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// The recovered volume keeps its limits, which are replaced by the
+	// configured ones.
+	if _, err := client.Volumes.SetVolume(v.Name, qosData(d, true)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourcePureVolumeRead(ctx, d, m)
 }
 
@@ -141,26 +282,64 @@ func resourcePureVolumeRead(ctx context.Context, d *schema.ResourceData, m inter
 		return nil
 	}
 
-	splitName := strings.Split(vol.Name, "/")
-	if len(splitName) < 1 || len(splitName) > 2 {
-		return diag.Errorf("invalid name '%s'", vol.Name)
-	} else if len(splitName) == 1 {
-		d.Set("name", splitName[0])
-		d.Set("volume_group", nil)
-	} else if len(splitName) == 2 {
-		d.Set("name", splitName[1])
-		d.Set("volume_group", splitName[0])
+	pod, volumeGroup, name, err := splitVolumeName(vol.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("pod", pod)
+	d.Set("volume_group", volumeGroup)
+	d.Set("name", name)
+
+	// Only the protection groups of protection_group are managed, so that
+	// the volume can be added to other protection groups by
+	// purefa_protectiongroup or purefa_protectiongroup_volume.
+	pgroups, err := getVolumeProtectionGroups(client, vol.Name)
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	var managed []string
+	for _, p := range d.Get("protection_group").(*schema.Set).List() {
+		if stringInSlice(p.(string), pgroups) {
+			managed = append(managed, p.(string))
+		}
+	}
+	d.Set("protection_group", managed)
+
+	qos, err := getQos(client, "volume", vol.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setQos(d, qos)
 
 	d.Set("full_name", vol.Name)
-	d.Set("size", vol.Size)
+	d.Set("size", strconv.Itoa(vol.Size))
+	d.Set("size_bytes", vol.Size)
+	d.Set("size_human", formatSize(vol.Size))
 	d.Set("serial", vol.Serial)
 	d.Set("created", vol.Created)
-	d.Set("source", vol.Source)
-	d.Set("allow_destroy", d.Get("allow_destroy").(bool))
+	// A copy of a snapshot reports the volume of the snapshot as its source.
+	// Volume snapshots are named vol.suffix, and the volume snapshots in a
+	// protection group snapshot pgroup.suffix.vol.
+	source := d.Get("source").(string)
+	if !strings.HasPrefix(source, vol.Source+".") && !strings.HasSuffix(source, "."+vol.Source) {
+		d.Set("source", vol.Source)
+	}
 	return nil
 }
 
+// resourcePureVolumeImport imports a volume with all of its protection
+// groups.
+func resourcePureVolumeImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*flasharray.Client)
+
+	pgroups, err := getVolumeProtectionGroups(client, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("protection_group", pgroups)
+	return []*schema.ResourceData{d}, nil
+}
+
 // resourcePureVolumeUpdate will update the attributes of the volume.
 //
 // If a new source is provided, a snapshot of the current volume will be
@@ -176,19 +355,23 @@ func resourcePureVolumeUpdate(ctx context.Context, d *schema.ResourceData, m int
 	var v *flasharray.Volume
 	var err error
 
-	if d.HasChange("volume_group") {
-		currentVolumeGroup, newVolumeGroup := d.GetChange("volume_group")
-		currentFullName := volumeFullName(currentVolumeGroup, d.Get("name"))
-		if v, err = client.Volumes.MoveVolume(currentFullName, newVolumeGroup.(string)); err != nil {
+	// A volume lives in at most one container, a pod or a volume group.
+	if d.HasChanges("pod", "volume_group") {
+		container := d.Get("pod").(string)
+		if container == "" {
+			container = d.Get("volume_group").(string)
+		}
+		if v, err = client.Volumes.MoveVolume(d.Id(), container); err != nil {
 			return diag.FromErr(err)
 		} else {
 			d.SetId(v.Name)
-			d.Set("volume_group", newVolumeGroup.(string))
+			d.Set("pod", d.Get("pod").(string))
+			d.Set("volume_group", d.Get("volume_group").(string))
 		}
 	}
 
 	if d.HasChange("name") {
-		newFullname := volumeFullName(d.Get("volume_group"), d.Get("name"))
+		newFullname := volumeFullName(d.Get("pod"), d.Get("volume_group"), d.Get("name"))
 		if v, err = client.Volumes.RenameVolume(d.Id(), newFullname); err != nil {
 			return diag.FromErr(err)
 		}
@@ -210,24 +393,77 @@ func resourcePureVolumeUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	if d.HasChange("size") {
 		oldVol, err := client.Volumes.GetVolume(d.Id(), nil)
-		z, _ := d.GetOk("size")
-		if z.(int) > oldVol.Size {
-			if _, err = client.Volumes.ExtendVolume(d.Id(), z.(int)); err != nil {
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		z, _ := parseSize(d.Get("size").(string))
+		if z > oldVol.Size {
+			if _, err = client.Volumes.ExtendVolume(d.Id(), z); err != nil {
 				return diag.FromErr(err)
 			}
 		}
-		if z.(int) < oldVol.Size {
+		if z < oldVol.Size {
 			return diag.Errorf("error: New size must be larger than current size. Truncating volumes not supported")
 		}
 	}
 
+	if d.HasChange("protection_group") {
+		if err := setVolumeProtectionGroups(client, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if data := qosData(d, false); len(data) > 0 {
+		if _, err := client.Volumes.SetVolume(d.Id(), data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourcePureVolumeRead(ctx, d, m)
 }
 
+// resourcePureVolumeCustomizeDiff refuses to shrink a volume at plan time,
+// since truncating volumes is not supported, and plans the new size
+// attributes.
+func resourcePureVolumeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("size") || !d.NewValueKnown("size") {
+		return nil
+	}
+	o, n := d.GetChange("size")
+	oldSize, err := parseSize(o.(string))
+	if err != nil {
+		return nil
+	}
+	newSize, err := parseSize(n.(string))
+	if err != nil {
+		return err
+	}
+	if newSize < oldSize {
+		return fmt.Errorf("the size of volume %s can not be shrunk from %s to %s, truncating volumes is not supported", d.Id(), formatSize(oldSize), formatSize(newSize))
+	}
+	if err := d.SetNew("size_bytes", newSize); err != nil {
+		return err
+	}
+	return d.SetNew("size_human", formatSize(newSize))
+}
+
+// validateVolumeSize checks that a volume size parses, and is a positive
+// multiple of 512 bytes.
+func validateVolumeSize(i interface{}, k string) ([]string, []error) {
+	size, err := parseSize(i.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+	if size <= 0 || size%512 != 0 {
+		return nil, []error{fmt.Errorf("%s must be a positive multiple of 512 bytes, got %d", k, size)}
+	}
+	return nil, nil
+}
+
 // resourcePureVolumeDelete will delete the volume specified.
-// The volume will NOT be eradicated. This is to reduce the chance of
-// data loss.  The volume's timer will start for 24 hours, at that time
-// the volume will be eradicated.
+// Unless eradicate_on_delete is set, the volume will NOT be eradicated. This
+// is to reduce the chance of data loss.  The volume's timer will start for
+// 24 hours, at that time the volume will be eradicated.
 func resourcePureVolumeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.Get("allow_destroy") == false {
 		return diag.Errorf("The `allow_destroy` parameter is set to false. The volume can not be destroyed through Terraform.")
@@ -240,13 +476,169 @@ func resourcePureVolumeDelete(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	if d.Get("eradicate_on_delete").(bool) {
+		if _, err := client.Volumes.EradicateVolume(d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
 
-func volumeFullName(volumeGroup interface{}, volumeName interface{}) string {
+func volumeFullName(pod interface{}, volumeGroup interface{}, volumeName interface{}) string {
+	if pod != nil && pod.(string) != "" {
+		return fmt.Sprintf("%s::%s", pod.(string), volumeName.(string))
+	}
 	if volumeGroup == nil || volumeGroup.(string) == "" {
 		return volumeName.(string)
 	}
 	return fmt.Sprintf("%s/%s", volumeGroup.(string), volumeName.(string))
 }
+
+// splitVolumeName splits the full name of a volume into its pod, volume group
+// and name.
+func splitVolumeName(fullName string) (pod string, volumeGroup string, name string, err error) {
+	name = fullName
+	if splitName := strings.SplitN(name, "::", 2); len(splitName) == 2 {
+		pod, name = splitName[0], splitName[1]
+	}
+	splitName := strings.Split(name, "/")
+	switch len(splitName) {
+	case 1:
+		name = splitName[0]
+	case 2:
+		volumeGroup, name = splitName[0], splitName[1]
+	default:
+		return "", "", "", fmt.Errorf("invalid name '%s'", fullName)
+	}
+	return pod, volumeGroup, name, nil
+}
+
+// getVolumeProtectionGroups returns the names of the protection groups a
+// volume is a member of.
+func getVolumeProtectionGroups(client *flasharray.Client, name string) ([]string, error) {
+	// The pugo sdk does not support listing the protection groups of a
+	// volume.
+	req, err := client.NewRequest("GET", fmt.Sprintf("volume/%s", name), map[string]string{"protect": "true"}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var memberships []flasharray.VolumePgroup
+	if _, err := client.Do(req, &memberships, false); err != nil {
+		return nil, err
+	}
+	var pgroups []string
+	for _, p := range memberships {
+		pgroups = append(pgroups, p.Pgroup)
+	}
+	return pgroups, nil
+}
+
+// setVolumeProtectionGroups adds the volume to the protection groups added to
+// protection_group, and removes it from the ones removed from it. The other
+// protection groups of the volume are left alone.
+func setVolumeProtectionGroups(client *flasharray.Client, d *schema.ResourceData) error {
+	current, err := getVolumeProtectionGroups(client, d.Id())
+	if err != nil {
+		return err
+	}
+	o, n := d.GetChange("protection_group")
+	os, ns := o.(*schema.Set), n.(*schema.Set)
+	for _, p := range os.Difference(ns).List() {
+		if stringInSlice(p.(string), current) {
+			if _, err := client.Volumes.RemoveVolume(d.Id(), p.(string)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, p := range ns.Difference(os).List() {
+		if !stringInSlice(p.(string), current) {
+			if _, err := client.Volumes.AddVolume(d.Id(), p.(string)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// The QoS limits the array accepts.
+const (
+	minBandwidthLimit = 1024 * 1024
+	maxBandwidthLimit = 512 * 1024 * 1024 * 1024
+	minIopsLimit      = 100
+	maxIopsLimit      = 100000000
+)
+
+// pureQos is the QoS of a volume or volume group.
+// The pugo sdk does not support QoS limits.
+type pureQos struct {
+	Name           string `json:"name"`
+	BandwidthLimit *int   `json:"bandwidth_limit"`
+	IopsLimit      *int   `json:"iops_limit"`
+}
+
+// qosSchema adds the bandwidth_limit and iops_limit arguments of volumes and
+// volume groups to the given schema.
+func qosSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["bandwidth_limit"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "Maximum bandwidth in bytes per second, with an optional K, M, G or T suffix in multiples of 1024, e.g. 500M. Between 1M and 512G.",
+		ValidateFunc:     validateUnits(1024, minBandwidthLimit, maxBandwidthLimit),
+		DiffSuppressFunc: suppressEquivalentUnits(1024),
+	}
+	s["iops_limit"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "Maximum number of I/O operations per second, with an optional K or M suffix in multiples of 1000, e.g. 10K. Between 100 and 100M.",
+		ValidateFunc:     validateUnits(1000, minIopsLimit, maxIopsLimit),
+		DiffSuppressFunc: suppressEquivalentUnits(1000),
+	}
+	return s
+}
+
+// getQos returns the QoS limits of a volume or volume group.
+func getQos(client *flasharray.Client, collection string, name string) (*pureQos, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("%s/%s", collection, name), map[string]string{"qos": "true"}, nil)
+	if err != nil {
+		return nil, err
+	}
+	qos := &pureQos{}
+	if _, err := client.Do(req, qos, false); err != nil {
+		return nil, err
+	}
+	return qos, nil
+}
+
+// setQos sets the QoS limits read from the array. A limit that is not set is
+// read as an empty string.
+func setQos(d *schema.ResourceData, qos *pureQos) {
+	for k, limit := range map[string]*int{"bandwidth_limit": qos.BandwidthLimit, "iops_limit": qos.IopsLimit} {
+		if limit == nil {
+			d.Set(k, "")
+		} else {
+			d.Set(k, strconv.Itoa(*limit))
+		}
+	}
+}
+
+// qosData returns the changed QoS limits, or all of them, to set on a volume
+// or volume group. A limit that is not configured is removed with an empty
+// string.
+func qosData(d *schema.ResourceData, all bool) map[string]interface{} {
+	data := make(map[string]interface{})
+	bases := map[string]int{"bandwidth_limit": 1024, "iops_limit": 1000}
+	for k, base := range bases {
+		if !all && !d.HasChange(k) {
+			continue
+		}
+		// Only an empty limit does not parse, since the limits are validated.
+		if limit, err := parseUnits(d.Get(k).(string), base); err == nil {
+			data[k] = limit
+		} else {
+			data[k] = ""
+		}
+	}
+	return data
+}
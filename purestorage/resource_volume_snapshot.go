@@ -0,0 +1,179 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePureVolumeSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePureVolumeSnapshotCreate,
+		ReadContext:   resourcePureVolumeSnapshotRead,
+		UpdateContext: resourcePureVolumeSnapshotUpdate,
+		DeleteContext: resourcePureVolumeSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePureVolumeSnapshotImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"volume": {
+				Description: "Full name of the volume to snapshot.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"suffix": {
+				Description:  "Suffix of the snapshot name. The array generates a suffix when it is not set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[\w\-\d]+$`), "can only contain letters, numbers and '-'"),
+			},
+			"eradicate_on_delete": {
+				Description: "When set to true, the snapshot is eradicated after it is destroyed instead of waiting for the eradication timer.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"name": {
+				Description: "Name of the snapshot, in the form volume.suffix.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"serial": {
+				Description: "A globally unique serial number generated by the system when the snapshot is created.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"size": {
+				Description: "Provisioned size of the snapshot in bytes.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"created": {
+				Description: "Snapshot creation time.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourcePureVolumeSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	snapshot, err := client.Volumes.CreateSnapshot(d.Get("volume").(string), d.Get("suffix").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(snapshot.Name)
+
+	return resourcePureVolumeSnapshotRead(ctx, d, m)
+}
+
+func resourcePureVolumeSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	volume, suffix := splitSnapshotName(d.Id())
+	snapshots, _ := listVolumeSnapshots(client, volume)
+
+	var snapshot *flasharray.Volume
+	for i := range snapshots {
+		if snapshots[i].Name == d.Id() {
+			snapshot = &snapshots[i]
+		}
+	}
+	if snapshot == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("volume", volume)
+	d.Set("suffix", suffix)
+	d.Set("name", snapshot.Name)
+	d.Set("serial", snapshot.Serial)
+	d.Set("size", snapshot.Size)
+	d.Set("created", snapshot.Created)
+	return nil
+}
+
+// resourcePureVolumeSnapshotUpdate only has to handle eradicate_on_delete,
+// which is not stored on the array.
+func resourcePureVolumeSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePureVolumeSnapshotRead(ctx, d, m)
+}
+
+// resourcePureVolumeSnapshotDelete destroys the snapshot, and eradicates it
+// if eradicate_on_delete is set.
+func resourcePureVolumeSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*flasharray.Client)
+
+	if _, err := client.Volumes.DeleteVolume(d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("eradicate_on_delete").(bool) {
+		if _, err := client.Volumes.EradicateVolume(d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePureVolumeSnapshotImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, suffix := splitSnapshotName(d.Id()); suffix == "" {
+		return nil, fmt.Errorf("invalid snapshot name '%s', expected volume.suffix", d.Id())
+	}
+	d.Set("eradicate_on_delete", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// splitSnapshotName splits a volume snapshot name into the volume name and
+// the suffix.
+func splitSnapshotName(name string) (string, string) {
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return name, ""
+	}
+	return name[:i], name[i+1:]
+}
+
+// listVolumeSnapshots returns the snapshots of a volume.
+// The pugo sdk does not support listing the snapshots of a single volume.
+func listVolumeSnapshots(client *flasharray.Client, volume string) ([]flasharray.Volume, error) {
+	params := map[string]string{"snap": "true"}
+	req, err := client.NewRequest("GET", fmt.Sprintf("volume/%s", volume), params, nil)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := []flasharray.Volume{}
+	if _, err := client.Do(req, &snapshots, false); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
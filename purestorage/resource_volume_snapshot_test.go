@@ -0,0 +1,163 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCheckPureVolumeSnapshotResourceName = "purefa_volume_snapshot.tfsnapshottest"
+
+// Take a snapshot of a volume, and create a new volume from it
+func TestAccResourcePureVolumeSnapshot_create(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPureVolumeSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPureVolumeSnapshotConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPureVolumeSnapshotExists(testAccCheckPureVolumeSnapshotResourceName, true),
+					resource.TestCheckResourceAttr(testAccCheckPureVolumeSnapshotResourceName, "name", fmt.Sprintf("tfsnapshotvolume-%d.tfsnap", rInt)),
+					resource.TestCheckResourceAttr("purefa_volume.tfsnapshotclone", "source", fmt.Sprintf("tfsnapshotvolume-%d.tfsnap", rInt)),
+					resource.TestCheckResourceAttr("data.purefa_volume_snapshots.tfsnapshots", "snapshots.#", "1"),
+				),
+			},
+			{
+				ResourceName:            testAccCheckPureVolumeSnapshotResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"eradicate_on_delete"},
+			},
+		},
+	})
+}
+
+// Snapshot a volume, clone it, list the snapshots, import and eradicate
+// against the fake array.
+func TestResourcePureVolumeSnapshot_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVolumeSnapshot()
+
+	if _, err := client.Volumes.CreateVolume("tfsnapshotvolume", 1048576); err != nil {
+		t.Fatalf("error creating volume: %s", err)
+	}
+
+	config := map[string]interface{}{"volume": "tfsnapshotvolume", "suffix": "tfsnap", "eradicate_on_delete": true}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "tfsnapshotvolume.tfsnap" || state.Attributes["size"] != "1048576" || state.Attributes["serial"] == "" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "eradicate_on_delete")
+
+	clone := map[string]interface{}{"name": "tfsnapshotclone", "source": state.ID, "allow_destroy": true}
+	testFakeApply(t, resourcePureVolume(), nil, clone, client)
+	if v := fa.volumes["tfsnapshotclone"]; v == nil || v.Size != 1048576 {
+		t.Fatalf("volume was not copied from the snapshot: %#v", v)
+	}
+
+	d := testFakeRead(t, dataSourcePureVolumeSnapshots(), map[string]interface{}{"volume": "tfsnapshotvolume"}, client)
+	snapshots := d.Get("snapshots").([]interface{})
+	if len(snapshots) != 1 || snapshots[0].(map[string]interface{})["suffix"] != "tfsnap" {
+		t.Fatalf("unexpected snapshots: %v", snapshots)
+	}
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.snapshots["tfsnapshotvolume.tfsnap"]; ok {
+		t.Fatalf("snapshot was not eradicated")
+	}
+}
+
+func testAccCheckPureVolumeSnapshotDestroy(s *terraform.State) error {
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "purefa_volume_snapshot" {
+			continue
+		}
+
+		_, err := client.Volumes.GetVolume(rs.Primary.ID, nil)
+		if err == nil {
+			return fmt.Errorf("snapshot '%s' still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPureVolumeSnapshotExists(n string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no ID is set")
+		}
+
+		client := testAccClient()
+		volume, _ := splitSnapshotName(rs.Primary.ID)
+		snapshots, err := listVolumeSnapshots(client, volume)
+		if err != nil {
+			return err
+		}
+		for _, snapshot := range snapshots {
+			if snapshot.Name == rs.Primary.ID {
+				return nil
+			}
+		}
+		if exists {
+			return fmt.Errorf("snapshot does not exist: %s", n)
+		}
+		return nil
+	}
+}
+
+func testAccCheckPureVolumeSnapshotConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "purefa_volume" "tfsnapshotvolume" {
+	name          = "tfsnapshotvolume-%d"
+	size          = 1024000000
+	allow_destroy = true
+}
+
+resource "purefa_volume_snapshot" "tfsnapshottest" {
+	volume              = purefa_volume.tfsnapshotvolume.name
+	suffix              = "tfsnap"
+	eradicate_on_delete = true
+}
+
+resource "purefa_volume" "tfsnapshotclone" {
+	name          = "tfsnapshotclone-%d"
+	source        = purefa_volume_snapshot.tfsnapshottest.name
+	allow_destroy = true
+}
+
+data "purefa_volume_snapshots" "tfsnapshots" {
+	volume = purefa_volume_snapshot.tfsnapshottest.volume
+}`, rInt, rInt)
+}
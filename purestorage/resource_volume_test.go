@@ -17,13 +17,15 @@
 package purestorage
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"strings"
 	"testing"
 
-	"github.com/devans10/pugo/flasharray"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -120,8 +122,217 @@ func TestAccResourcePureVolume_update(t *testing.T) {
 	})
 }
 
+// Create, update, import and destroy a volume against the fake array.
+func TestResourcePureVolume_lifecycle(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVolume()
+
+	if _, err := client.Vgroups.CreateVgroup("tfvgroup"); err != nil {
+		t.Fatalf("error creating volume group: %s", err)
+	}
+
+	config := map[string]interface{}{"name": "tfvolumetest", "size": 1024000000, "allow_destroy": true}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "tfvolumetest" || state.Attributes["serial"] == "" || state.Attributes["size"] != "1024000000" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	config["size"] = 2048000000
+	state = testFakeApply(t, r, state, config, client)
+	if fa.volumes["tfvolumetest"].Size != 2048000000 {
+		t.Fatalf("volume was not extended")
+	}
+
+	config["volume_group"] = "tfvgroup"
+	state = testFakeApply(t, r, state, config, client)
+
+	config["name"] = "tfvolumetest-renamed"
+	state = testFakeApply(t, r, state, config, client)
+	if state.ID != "tfvgroup/tfvolumetest-renamed" || state.Attributes["full_name"] != "tfvgroup/tfvolumetest-renamed" {
+		t.Fatalf("unexpected state after rename: %v", state)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "allow_destroy", "eradicate_on_delete", "recover_if_destroyed")
+
+	clone := testFakeApply(t, r, nil, map[string]interface{}{"name": "tfclonevolumetest", "source": state.ID, "allow_destroy": true}, client)
+	if clone.Attributes["size"] != "2048000000" || clone.Attributes["source"] != state.ID {
+		t.Fatalf("unexpected state after clone: %v", clone)
+	}
+
+	testFakeDestroy(t, r, clone, client)
+	testFakeDestroy(t, r, state, client)
+	if v := fa.volumes["tfvgroup/tfvolumetest-renamed"]; v == nil || !v.Destroyed {
+		t.Fatalf("volume was not destroyed")
+	}
+	if testFakeRefresh(t, r, state, client) != nil {
+		t.Fatalf("destroyed volume is still read")
+	}
+}
+
+// Create a volume in a pod and move it out of it against the fake array.
+func TestResourcePureVolume_pod(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVolume()
+
+	if _, err := client.Pods.CreatePod("tfpod", nil); err != nil {
+		t.Fatalf("error creating pod: %s", err)
+	}
+
+	config := map[string]interface{}{"name": "tfvolumetest", "size": 1024000000, "pod": "tfpod", "allow_destroy": true}
+	state := testFakeApply(t, r, nil, config, client)
+	if state.ID != "tfpod::tfvolumetest" || state.Attributes["pod"] != "tfpod" || state.Attributes["volume_group"] != "" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "allow_destroy", "eradicate_on_delete", "recover_if_destroyed")
+
+	delete(config, "pod")
+	state = testFakeApply(t, r, state, config, client)
+	if _, ok := fa.volumes["tfvolumetest"]; !ok || state.ID != "tfvolumetest" {
+		t.Fatalf("volume was not moved out of the pod: %v", state)
+	}
+}
+
+// Size a volume with units, and refuse to shrink it at plan time.
+func TestResourcePureVolume_sizeUnits(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVolume()
+
+	config := map[string]interface{}{"name": "tfvolumetest", "size": "1G"}
+	state := testFakeApply(t, r, nil, config, client)
+	if fa.volumes["tfvolumetest"].Size != 1<<30 || state.Attributes["size"] != "1073741824" || state.Attributes["size_bytes"] != "1073741824" || state.Attributes["size_human"] != "1G" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+
+	// An equivalent size is not a change.
+	config["size"] = "1024M"
+	state = testFakeApply(t, r, state, config, client)
+
+	config["size"] = "1.5GiB"
+	state = testFakeApply(t, r, state, config, client)
+	if fa.volumes["tfvolumetest"].Size != 3<<29 || state.Attributes["size_human"] != "1.5G" {
+		t.Fatalf("volume was not extended: %v", state)
+	}
+
+	// The shrink fails before the volume is renamed.
+	config["name"] = "tfvolumetest-rename"
+	config["size"] = "1G"
+	if diags := testFakeApplyError(t, r, state, config, client); !strings.Contains(fmt.Sprint(diags), "can not be shrunk") {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, ok := fa.volumes["tfvolumetest"]; !ok {
+		t.Fatalf("volume was renamed")
+	}
+}
+
+// Convert the size in bytes of a version 0 state to a string.
+func Test_resourcePureVolumeStateUpgradeV0(t *testing.T) {
+	for _, size := range []interface{}{json.Number("1073741824"), float64(1073741824)} {
+		state, err := resourcePureVolumeStateUpgradeV0(context.Background(), map[string]interface{}{"id": "tfvolumetest", "size": size}, nil)
+		if err != nil {
+			t.Fatalf("error upgrading state: %s", err)
+		}
+		if state["size"] != "1073741824" || state["size_bytes"] != int64(1073741824) || state["size_human"] != "1G" {
+			t.Fatalf("unexpected state: %v", state)
+		}
+	}
+
+	if _, err := resourcePureVolumeStateUpgradeV0(context.Background(), map[string]interface{}{"size": json.Number("1.5")}, nil); err == nil {
+		t.Fatalf("expected an error upgrading a fractional size")
+	}
+
+	if v0 := resourcePureVolumeV0(resourcePureVolume()); v0.Schema["size"].Type != schema.TypeInt || v0.Schema["size_bytes"] != nil {
+		t.Fatalf("unexpected version 0 schema")
+	}
+}
+
+// Manage the protection groups of a volume.
+func TestResourcePureVolume_protectionGroup(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVolume()
+
+	for _, name := range []string{"tfvolpgroup1", "tfvolpgroup2", "tfvolpgroup3"} {
+		fa.pgroups[name] = &fakePgroup{Name: name, Source: fa.ArrayName}
+	}
+
+	config := map[string]interface{}{"name": "tfvolumetest", "size": 1048576, "protection_group": []interface{}{"tfvolpgroup1"}}
+	state := testFakeApply(t, r, nil, config, client)
+	if fmt.Sprint(fa.pgroups["tfvolpgroup1"].Volumes) != "[tfvolumetest]" || state.Attributes["protection_group.#"] != "1" {
+		t.Fatalf("volume was not added to the protection group: %v", state)
+	}
+
+	// A protection group the volume was added to elsewhere is not managed.
+	fa.pgroups["tfvolpgroup3"].Volumes = []string{"tfvolumetest"}
+	config["protection_group"] = []interface{}{"tfvolpgroup2"}
+	state = testFakeApply(t, r, state, config, client)
+	if len(fa.pgroups["tfvolpgroup1"].Volumes) != 0 || fmt.Sprint(fa.pgroups["tfvolpgroup2"].Volumes) != "[tfvolumetest]" {
+		t.Fatalf("volume was not moved to the other protection group")
+	}
+	if fmt.Sprint(fa.pgroups["tfvolpgroup3"].Volumes) != "[tfvolumetest]" || state.Attributes["protection_group.#"] != "1" {
+		t.Fatalf("unmanaged protection group was changed: %v", state)
+	}
+
+	// Importing captures all the protection groups of the volume.
+	fa.pgroups["tfvolpgroup3"].Volumes = nil
+	testFakeImportVerify(t, r, state.ID, state, client, "allow_destroy", "eradicate_on_delete", "recover_if_destroyed")
+
+	// Without protection_group the memberships are left alone.
+	delete(config, "protection_group")
+	state = testFakeApply(t, r, state, config, client)
+	if fmt.Sprint(fa.pgroups["tfvolpgroup2"].Volumes) != "[tfvolumetest]" || state.Attributes["protection_group.#"] != "1" {
+		t.Fatalf("protection group membership was changed: %v", state)
+	}
+}
+
+// Set, change and remove the QoS limits of a volume.
+func TestResourcePureVolume_qos(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVolume()
+
+	config := map[string]interface{}{"name": "tfvolumetest", "size": 1048576, "bandwidth_limit": "500M", "iops_limit": "10K"}
+	state := testFakeApply(t, r, nil, config, client)
+	if v := fa.volumes["tfvolumetest"]; v.BandwidthLimit != 500*1024*1024 || v.IopsLimit != 10000 {
+		t.Fatalf("unexpected limits after create: %#v", v.fakeQos)
+	}
+
+	testFakeImportVerify(t, r, state.ID, state, client, "allow_destroy", "eradicate_on_delete", "recover_if_destroyed")
+
+	config["bandwidth_limit"] = "1G"
+	delete(config, "iops_limit")
+	state = testFakeApply(t, r, state, config, client)
+	if v := fa.volumes["tfvolumetest"]; v.BandwidthLimit != 1024*1024*1024 || v.IopsLimit != 0 || state.Attributes["iops_limit"] != "" {
+		t.Fatalf("unexpected limits after update: %#v", v.fakeQos)
+	}
+}
+
+// Re-create a destroyed volume by recovering it, then eradicate it.
+func TestResourcePureVolume_recover(t *testing.T) {
+	fa, client := testFakeArray(t)
+	r := resourcePureVolume()
+
+	config := map[string]interface{}{"name": "tfvolumetest", "size": 1048576, "allow_destroy": true}
+	state := testFakeApply(t, r, nil, config, client)
+	serial := state.Attributes["serial"]
+	testFakeDestroy(t, r, state, client)
+
+	testFakeApplyError(t, r, nil, config, client)
+
+	config["size"] = 2097152
+	config["recover_if_destroyed"] = true
+	config["eradicate_on_delete"] = true
+	state = testFakeApply(t, r, nil, config, client)
+	if state.Attributes["serial"] != serial || state.Attributes["size"] != "2097152" {
+		t.Fatalf("volume was not recovered: %v", state)
+	}
+
+	testFakeDestroy(t, r, state, client)
+	if _, ok := fa.volumes["tfvolumetest"]; ok {
+		t.Fatalf("volume was not eradicated")
+	}
+}
+
 func testAccCheckPureVolumeDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*flasharray.Client)
+	client := testAccClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "purefa_volume" {
@@ -155,7 +366,7 @@ type Volume struct {
 // Checks if resources are still pending for deletion and eredicates if needed
 // The pugo sdk does not support listing deleted volumegroups. This method includes a temporary fix.
 func testAccCheckPureVolumeEradicate(s *terraform.State) error {
-	client := testAccProvider.Meta().(*flasharray.Client)
+	client := testAccClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "purefa_volume" {
@@ -190,7 +401,7 @@ func testAccCheckPureVolumeExists(n string, exists bool) resource.TestCheckFunc
 			return fmt.Errorf("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		_, err := client.Volumes.GetVolume(rs.Primary.ID, nil)
 		if err != nil {
 			if exists {
@@ -218,7 +429,7 @@ func testAccCheckPureVolumeCount(testID string, count int) resource.TestCheckFun
 			}
 		}
 
-		client := testAccProvider.Meta().(*flasharray.Client)
+		client := testAccClient()
 		if volumes, err := client.Volumes.ListVolumes(nil); err == nil {
 			for _, volume := range volumes {
 				if strings.Contains(volume.Name, testID) {
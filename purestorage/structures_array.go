@@ -0,0 +1,35 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"github.com/devans10/pugo/flasharray"
+)
+
+func flattenArrayPerformance(in flasharray.Array) []interface{} {
+	m := make(map[string]interface{})
+	m["reads_per_sec"] = in.ReadsPerSec
+	m["writes_per_sec"] = in.WritesPerSec
+	m["input_per_sec"] = in.InputPerSec
+	m["output_per_sec"] = in.OutputPerSec
+	m["usec_per_read_op"] = in.UsecPerReadOp
+	m["usec_per_write_op"] = in.UsecPerWriteOp
+	m["san_usec_per_read_op"] = in.SanUsecPerReadOp
+	m["san_usec_per_write_op"] = in.SanUsecPerWriteOp
+	m["queue_depth"] = in.QueueDepth
+	return []interface{}{m}
+}
@@ -31,3 +31,14 @@ func flattenHgroupVolume(in []flasharray.HostgroupConnection) []map[string]inter
 	}
 	return out
 }
+
+func flattenHostgroups(in []flasharray.Hostgroup) []interface{} {
+	var out = make([]interface{}, 0, len(in))
+	for _, hg := range in {
+		m := make(map[string]interface{})
+		m["name"] = hg.Name
+		m["hosts"] = hg.Hosts
+		out = append(out, m)
+	}
+	return out
+}
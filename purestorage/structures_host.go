@@ -18,6 +18,7 @@ package purestorage
 
 import (
 	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func flattenVolume(in []flasharray.ConnectedVolume) []map[string]interface{} {
@@ -31,3 +32,29 @@ func flattenVolume(in []flasharray.ConnectedVolume) []map[string]interface{} {
 	}
 	return out
 }
+
+func flattenHosts(in []flasharray.Host) []interface{} {
+	var out = make([]interface{}, 0, len(in))
+	for _, h := range in {
+		m := make(map[string]interface{})
+		m["name"] = h.Name
+		m["hostgroup"] = h.Hgroup
+		m["wwn"] = h.Wwn
+		m["iqn"] = h.Iqn
+		m["nqn"] = h.Nqn
+		m["personality"] = h.Personality
+		m["preferred_array"] = h.PreferredArray
+		out = append(out, m)
+	}
+	return out
+}
+
+// volumeBlockNames returns the names of the volumes in a volume block of a
+// host or host group.
+func volumeBlockNames(in *schema.Set) []string {
+	var out []string
+	for _, v := range in.List() {
+		out = append(out, v.(map[string]interface{})["vol"].(string))
+	}
+	return out
+}
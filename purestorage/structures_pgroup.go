@@ -0,0 +1,76 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"github.com/devans10/pugo/flasharray"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func flattenProtectiongroups(in []flasharray.Protectiongroup) []interface{} {
+	var out = make([]interface{}, 0, len(in))
+	for _, pg := range in {
+		var targets []string
+		for _, t := range pg.Targets {
+			if name, ok := t["name"].(string); ok {
+				targets = append(targets, name)
+			}
+		}
+		m := make(map[string]interface{})
+		m["name"] = pg.Name
+		m["source"] = pg.Source
+		m["volumes"] = pg.Volumes
+		m["hosts"] = pg.Hosts
+		m["hgroups"] = pg.Hgroups
+		m["targets"] = targets
+		out = append(out, m)
+	}
+	return out
+}
+
+// flattenPgroupTargets flattens the replication targets of a protection
+// group into target blocks.
+func flattenPgroupTargets(in []map[string]interface{}) []interface{} {
+	var out = make([]interface{}, 0, len(in))
+	for _, t := range in {
+		name, ok := t["name"].(string)
+		if !ok {
+			continue
+		}
+		allowed, _ := t["allowed"].(bool)
+		out = append(out, map[string]interface{}{
+			"name":    name,
+			"allowed": allowed,
+		})
+	}
+	return out
+}
+
+// pgroupTargetNames returns the array names of a set of target blocks.
+func pgroupTargetNames(targets *schema.Set) []string {
+	var names []string
+	for _, t := range targets.List() {
+		names = append(names, t.(map[string]interface{})["name"].(string))
+	}
+	return names
+}
+
+// pgroupTargetHash hashes a target block by its array name only, since
+// allowed is set on the target array.
+func pgroupTargetHash(v interface{}) int {
+	return schema.HashString(v.(map[string]interface{})["name"])
+}
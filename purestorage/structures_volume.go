@@ -0,0 +1,54 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package purestorage
+
+import (
+	"github.com/devans10/pugo/flasharray"
+)
+
+func flattenVolumeSnapshots(in []flasharray.Volume) []interface{} {
+	var out = make([]interface{}, 0, len(in))
+	for _, v := range in {
+		_, suffix := splitSnapshotName(v.Name)
+		m := make(map[string]interface{})
+		m["name"] = v.Name
+		m["suffix"] = suffix
+		m["serial"] = v.Serial
+		m["size"] = v.Size
+		m["created"] = v.Created
+		out = append(out, m)
+	}
+	return out
+}
+
+func flattenVolumes(in []flasharray.Volume) []interface{} {
+	var out = make([]interface{}, 0, len(in))
+	for _, v := range in {
+		pod, volumeGroup, name, _ := splitVolumeName(v.Name)
+		m := make(map[string]interface{})
+		m["name"] = name
+		m["full_name"] = v.Name
+		m["volume_group"] = volumeGroup
+		m["pod"] = pod
+		m["size"] = v.Size
+		m["source"] = v.Source
+		m["serial"] = v.Serial
+		m["created"] = v.Created
+		out = append(out, m)
+	}
+	return out
+}
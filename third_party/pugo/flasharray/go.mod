@@ -0,0 +1,3 @@
+module github.com/devans10/pugo/flasharray
+
+go 1.12
@@ -0,0 +1,25 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package flasharray
+
+// SMTP struct for object returned by array
+type SMTP struct {
+	Password     string `json:"password,omitempty"`
+	Username     string `json:"user_name,omitempty"`
+	RelayHost    string `json:"relay_host,omitempty"`
+	SenderDomain string `json:"sender_domain,omitempty"`
+}
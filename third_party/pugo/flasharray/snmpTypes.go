@@ -0,0 +1,32 @@
+/*
+   Copyright 2018 David Evans
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package flasharray
+
+// SnmpManager struct for object returned by array
+type SnmpManager struct {
+	Name              string `json:"name"`
+	Notification      string `json:"notification"`
+	Community         string `json:"community"`
+	PrivacyProtocol   string `json:"privacy_protocol"`
+	AuthProtocol      string `json:"auth_protocol"`
+	Host              string `json:"host"`
+	Version           string `json:"version"`
+	User              string `json:"user"`
+	PrivacyPassphrase string `json:"privacy_passphrase"`
+	AuthPassphrase    string `json:"auth_passphrase"`
+	EngineID          string `json:"engine_id"`
+}